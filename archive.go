@@ -0,0 +1,150 @@
+package logger
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+// Archive will write the log files with filename timestamps within [from, to] to a .tar.gz file at destPath
+// Note: The currently opened file is never archived. The archive is written to a temporary file and renamed into place,
+// so destPath never contains a partial archive. When deleteAfter is set, the archived files are removed once the
+// archive is complete
+func (l *Logger) Archive(from, to time.Time, destPath string, deleteAfter, overwrite bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	dir, name, current, tmp, discard := l.dir, l.name, l.filename, l.tmpFilename, l.discard
+	// Release lock, the files are archived without holding the lock
+	l.mu.Unlock()
+
+	if discard {
+		// Discard loggers do not produce any files, return
+		return ErrNoFilesToArchive
+	}
+
+	if _, err = os.Stat(destPath); err == nil && !overwrite {
+		return ErrArchiveExists
+	}
+
+	var files []logFile
+	if files, err = listFiles(dir, name); err != nil {
+		return
+	}
+
+	var filenames []string
+	for _, file := range files {
+		if file.filename == current || file.filename == tmp {
+			// File is currently opened, continue
+			continue
+		}
+
+		if file.ts.Before(from) || file.ts.After(to) {
+			// File is outside of the time range, continue
+			continue
+		}
+
+		filenames = append(filenames, file.filename)
+	}
+
+	if len(filenames) == 0 {
+		return ErrNoFilesToArchive
+	}
+
+	if err = writeArchive(destPath, filenames); err != nil {
+		return
+	}
+
+	if !deleteAfter {
+		return
+	}
+
+	var errs errors.ErrorList
+	for _, filename := range filenames {
+		if err = os.Remove(filename); err != nil {
+			errs.Push(fmt.Errorf("error removing archived file: %v", err))
+			continue
+		}
+
+		// Remove checksum sidecar (if it exists)
+		os.Remove(filename + checksumExt)
+	}
+
+	return errs.Err()
+}
+
+// writeArchive will write the provided files to a .tar.gz file at destPath by way of a temporary file
+func writeArchive(destPath string, filenames []string) (err error) {
+	tmpPath := destPath + tmpExt
+
+	var f *os.File
+	if f, err = os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644); err != nil {
+		return
+	}
+
+	if err = writeTarGz(f, filenames); err == nil {
+		err = f.Sync()
+	}
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		// We encountered an error while writing, remove the partial archive
+		os.Remove(tmpPath)
+		return
+	}
+
+	return os.Rename(tmpPath, destPath)
+}
+
+// writeTarGz will write the provided files as a gzipped tarball to w
+func writeTarGz(w io.Writer, filenames []string) (err error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, filename := range filenames {
+		if err = addToTar(tw, filename); err != nil {
+			return
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return
+	}
+
+	return gz.Close()
+}
+
+// addToTar will add a file to the tar writer using its base name
+func addToTar(tw *tar.Writer, filename string) (err error) {
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+	defer f.Close()
+
+	var info os.FileInfo
+	if info, err = f.Stat(); err != nil {
+		return
+	}
+
+	var hdr *tar.Header
+	if hdr, err = tar.FileInfoHeader(info, ""); err != nil {
+		return
+	}
+
+	hdr.Name = path.Base(filename)
+	if err = tw.WriteHeader(hdr); err != nil {
+		return
+	}
+
+	_, err = io.Copy(tw, f)
+	return
+}
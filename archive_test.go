@@ -0,0 +1,184 @@
+package logger
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestArchive(t *testing.T) {
+	var (
+		l *Logger
+
+		rotated []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	for i := 0; i < 3; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+
+		rotated = append(rotated, l.f.Name())
+		if err = l.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dest := path.Join(testDir, "archive.tar.gz")
+	if err = l.Archive(time.Time{}, time.Now(), dest, true, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	if names, err = readArchive(dest); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != len(rotated) {
+		t.Fatalf("invalid number of archived files, expected %d and received %d", len(rotated), len(names))
+	}
+
+	sort.Strings(names)
+	for i, filename := range rotated {
+		if names[i] != path.Base(filename) {
+			t.Fatalf("invalid archived file, expected \"%s\" and received \"%s\"", path.Base(filename), names[i])
+		}
+
+		if _, err = os.Stat(filename); !os.IsNotExist(err) {
+			t.Fatalf("invalid error, expected %v and received %v", os.ErrNotExist, err)
+		}
+	}
+
+	// The current file is never archived
+	if _, err = os.Stat(l.f.Name()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = os.Stat(dest + tmpExt); !os.IsNotExist(err) {
+		t.Fatalf("invalid error, expected %v and received %v", os.ErrNotExist, err)
+	}
+
+	if err = l.Archive(time.Time{}, time.Now(), dest, false, false); err != ErrArchiveExists {
+		t.Fatalf("invalid error, expected %v and received %v", ErrArchiveExists, err)
+	}
+
+	if err = l.Archive(time.Time{}, time.Now(), dest, false, true); err != ErrNoFilesToArchive {
+		t.Fatalf("invalid error, expected %v and received %v", ErrNoFilesToArchive, err)
+	}
+}
+
+func TestArchive_range(t *testing.T) {
+	var (
+		l *Logger
+
+		rotated []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	var from, to time.Time
+	for i := 0; i < 3; i++ {
+		if i == 1 {
+			from = time.Now()
+		}
+
+		if err = l.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 1 {
+			to = time.Now()
+		}
+
+		// Empty files are removed on rotation, write an entry to each file
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+
+		rotated = append(rotated, l.f.Name())
+	}
+
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := path.Join(testDir, "archive.tar.gz")
+	if err = l.Archive(from, to, dest, false, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	if names, err = readArchive(dest); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 1 {
+		t.Fatalf("invalid number of archived files, expected %d and received %d", 1, len(names))
+	}
+
+	if names[0] != path.Base(rotated[1]) {
+		t.Fatalf("invalid archived file, expected \"%s\" and received \"%s\"", path.Base(rotated[1]), names[0])
+	}
+
+	// Files are kept when deleteAfter is not set
+	for _, filename := range rotated {
+		if _, err = os.Stat(filename); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// readArchive will return the names of the files within a .tar.gz archive
+func readArchive(filename string) (names []string, err error) {
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+	defer f.Close()
+
+	var gz *gzip.Reader
+	if gz, err = gzip.NewReader(f); err != nil {
+		return
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		var hdr *tar.Header
+		if hdr, err = tr.Next(); err == io.EOF {
+			return names, nil
+		} else if err != nil {
+			return
+		}
+
+		names = append(names, hdr.Name)
+	}
+}
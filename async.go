@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+// drainInterval is the interval at which Drain checks whether the async queue has been drained
+const drainInterval = time.Millisecond
+
+// enqueue will add an entry to the async queue
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) enqueue(e entry) (err error) {
+	// Copy message, as the caller may re-use the provided slice once we return
+	e.msg = append([]byte(nil), e.msg...)
+
+	select {
+	case l.queue <- e:
+		// Entry is pending until it has been written, see Drain
+		l.queued.Add(1)
+		return
+	default:
+		// Queue is full, return
+		return ErrQueueFull
+	}
+}
+
+// asyncLoop will write entries from the queue until the queue is closed
+func (l *Logger) asyncLoop(queue <-chan entry, done chan struct{}) {
+	// Signal that the queue has been drained once the loop ends
+	defer close(done)
+
+	for e := range queue {
+		l.writeAsync(e)
+		l.queued.Add(-1)
+	}
+}
+
+// writeAsync will write an entry from the async queue
+func (l *Logger) writeAsync(e entry) {
+	var (
+		onError ErrorFn
+		err     error
+	)
+
+	defer func() {
+		if r := recover(); r != nil {
+			// We encountered a panic while writing, convert to an error
+			err = fmt.Errorf("panic during async write: %v", r)
+		}
+
+		if err != nil && onError != nil {
+			// We encountered an error, pass to the error handler
+			onError(err)
+		}
+	}()
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.unlock()
+	// Get error handler while the lock is held
+	onError = l.errorHandler()
+	// Write entry, the closed state is not checked as Close will wait for the queue to drain
+	err = l.writeEntry(e)
+}
+
+// closeQueue will close the async queue (if set) and wait for it to be drained
+func (l *Logger) closeQueue() {
+	// Acquire lock
+	l.mu.Lock()
+	queue, done := l.queue, l.queueDone
+	if queue != nil {
+		// Close queue, no new entries can be added as the lock is held
+		close(queue)
+		l.queue = nil
+	}
+	// Release lock
+	l.mu.Unlock()
+
+	if done != nil {
+		// Wait for remaining entries to be written
+		<-done
+		// Async mode ends once the queue has been drained
+		l.async.Set(false)
+	}
+}
+
+// Drain will wait for all queued entries to be written and flush them to the file, without closing the logger
+// Note: Entries queued while waiting are also waited for. An error is returned if the context is done before the queue
+// has been drained. While not in async mode, this is equivalent to Flush
+func (l *Logger) Drain(ctx context.Context) (err error) {
+	if l.queued.Load() > 0 {
+		ticker := time.NewTicker(drainInterval)
+		defer ticker.Stop()
+
+		for l.queued.Load() > 0 {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+
+	return l.Flush()
+}
+
+// SetAsync will enable async mode, where entries are written by a background goroutine
+// Note: While in async mode, logging will return ErrQueueFull when the queue is full
+func (l *Logger) SetAsync(queueDepth int) (err error) {
+	// Ensure queue depth is valid
+	if queueDepth < 1 {
+		return ErrInvalidQueueDepth
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	// Ensure the logger is not already async
+	if l.queue != nil {
+		return ErrIsAsync
+	}
+
+	l.queue = make(chan entry, queueDepth)
+	l.queueDone = make(chan struct{})
+	l.async.Set(true)
+	go l.asyncLoop(l.queue, l.queueDone)
+	return
+}
+
+// IsAsync will return whether or not async mode is enabled
+// Note: This does not acquire the lock, so it is safe to call from a tee writer
+func (l *Logger) IsAsync() bool {
+	return l.async.Get()
+}
@@ -0,0 +1,172 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAsync(t *testing.T) {
+	var (
+		l *Logger
+		v *Viewer
+
+		lineCount int
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetAsync(10); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetAsync(10); err != ErrIsAsync {
+		t.Fatalf("invalid error, expected %v and received %v", ErrIsAsync, err)
+	}
+
+	if err = testLogs(l, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err = NewViewer(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = v.ForEach(func(key string) (err error) {
+		var r *Reader
+		if r, err = NewReader(key); err != nil {
+			return
+		}
+		defer r.Close()
+
+		return r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+			lineCount++
+			return
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if lineCount != 10 {
+		t.Fatalf("invalid line count, expected %d and received %d", 10, lineCount)
+	}
+}
+
+func TestAsync_queue_full(t *testing.T) {
+	var (
+		l *Logger
+
+		release = make(chan struct{})
+		errC    = make(chan error, 1)
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetNumLines(1)
+	l.SetErrorHandler(func(err error) {
+		errC <- err
+	})
+
+	l.SetRotationHook(func(oldPath, newPath string) {
+		// Block the async loop until released
+		<-release
+		panic("rotation hook panic")
+	})
+
+	if err = l.SetAsync(1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for the async loop to pick up the first entry
+	for i := 0; i < 100 && len(l.queue) > 0; i++ {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err = l.LogString("#2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#3"); err != ErrQueueFull {
+		t.Fatalf("invalid error, expected %v and received %v", ErrQueueFull, err)
+	}
+
+	close(release)
+
+	select {
+	case <-errC:
+	case <-time.After(time.Second):
+		t.Fatal("expected panic to be passed to the error handler")
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDrain(t *testing.T) {
+	var (
+		l   *Logger
+		bs  []byte
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = NewWithOptions(testDir, testName, WithAsync(1000)); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	filename := l.f.Name()
+	for i := 0; i < 1000; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Drain(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if bs, err = ioutil.ReadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := bytes.Count(bs, newline); count != 1000 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 1000, count)
+	}
+
+	// Logger remains open after draining
+	if err = l.LogString("#1001"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// base64Marker precedes messages which have been Base64 encoded
+const base64Marker = "base64:"
+
+// encodeBase64Message will return the prefix and message Base64 encoded, preceded by the Base64 marker
+func encodeBase64Message(prefix, msg []byte) []byte {
+	raw := make([]byte, 0, len(prefix)+len(msg))
+	raw = append(raw, prefix...)
+	raw = append(raw, msg...)
+
+	out := make([]byte, len(base64Marker)+base64.StdEncoding.EncodedLen(len(raw)))
+	copy(out, base64Marker)
+	base64.StdEncoding.Encode(out[len(base64Marker):], raw)
+	return out
+}
+
+// hasBase64Marker will return whether or not the prefix and message begin with the Base64 marker
+func hasBase64Marker(prefix, msg []byte) bool {
+	if len(prefix) >= len(base64Marker) {
+		return strings.HasPrefix(string(prefix), base64Marker)
+	}
+
+	return string(prefix) == base64Marker[:len(prefix)] && strings.HasPrefix(string(msg), base64Marker[len(prefix):])
+}
+
+// decodeBase64Message will decode a message preceded by the Base64 marker
+// Note: Messages without the marker (or which cannot be decoded) are returned as-is
+func decodeBase64Message(msg string) string {
+	if !strings.HasPrefix(msg, base64Marker) {
+		return msg
+	}
+
+	bs, err := base64.StdEncoding.DecodeString(msg[len(base64Marker):])
+	if err != nil {
+		// Message is not Base64 encoded, return
+		return msg
+	}
+
+	return string(bs)
+}
+
+// SetBase64Messages will set whether or not messages are Base64 encoded, allowing arbitrary bytes (E.g. binary payloads
+// containing newlines or null bytes) to be logged
+// Note: When enabled, messages are not checked for newlines. The prefix and message are encoded (after hooks are
+// applied) and written preceded by "base64:", while the timestamp, level and other metadata remain unencoded. Readers
+// detect encoded messages by the marker and decode them, so messages which begin with the marker are always encoded
+// (even when Base64 messages are disabled) to keep them from being mistaken for an encoded message
+func (l *Logger) SetBase64Messages(encode bool) {
+	l.base64Messages.Set(encode)
+}
@@ -0,0 +1,128 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSetBase64Messages(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+		e Entry
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := make([]byte, 256)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+
+	if err = l.Info(msg); err != ErrMessageContainsNewline {
+		t.Fatalf("invalid error, expected %v and received %v", ErrMessageContainsNewline, err)
+	}
+
+	l.SetBase64Messages(true)
+	if err = l.SetPrefix([]byte("binary: ")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Info(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	l.UseJSON(true)
+	if err = l.Info(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Encoded messages are detected and decoded by the reader
+	if r, err = Open(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	expected := "binary: " + string(msg)
+	for i := 0; i < 2; i++ {
+		if e, err = r.Next(); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Level != LevelInfo.name() {
+			t.Fatalf("invalid level, expected \"%s\" and received \"%s\"", LevelInfo.name(), e.Level)
+		}
+
+		if e.Message != expected {
+			t.Fatalf("invalid message, expected %q and received %q", expected, e.Message)
+		}
+	}
+}
+
+func TestReader_Base64Marker(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+		e Entry
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	// Message resembles an encoded message, but Base64 messages are not enabled
+	msg := "base64:aGVsbG8="
+	if err = l.LogString(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	// Marker is split between the prefix and the message
+	if err = l.SetPrefix([]byte("base")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("64:aGVsbG8="); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for i := 0; i < 2; i++ {
+		if e, err = r.Next(); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Message != msg {
+			t.Fatalf("invalid message, expected %q and received %q", msg, e.Message)
+		}
+	}
+}
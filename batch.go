@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"fmt"
+
+	"github.com/hatchify/errors"
+)
+
+// BatchError is returned by LogMany when only some of the messages within a batch could not be written
+type BatchError struct {
+	// Errors for each message within the batch, the index of each error matches the index of it's message. A nil
+	// error indicates the message was written successfully
+	Errors []error
+}
+
+// Error will return the error message
+func (b *BatchError) Error() string {
+	var failed int
+	for _, err := range b.Errors {
+		if err != nil {
+			failed++
+		}
+	}
+
+	return fmt.Sprintf("%d of %d messages could not be written", failed, len(b.Errors))
+}
+
+// LogMany will log multiple messages while only acquiring the lock once
+// Note: If any of the messages contain a newline, the entire batch is rejected before any messages are written. If
+// only some of the messages fail to write, a *BatchError is returned. If all messages fail, the first error is returned
+func (l *Logger) LogMany(msgs [][]byte) (err error) {
+	// Ensure all messages are valid before acquiring lock
+	// Copy messages so truncation does not modify the caller's slice
+	msgs = append([][]byte(nil), msgs...)
+	for i, msg := range msgs {
+		// Truncate message to the max line length (if set)
+		msgs[i] = l.truncateMessage(msg)
+		if err = l.validateMessage(msgs[i]); err != nil {
+			return
+		}
+	}
+
+	// Ensure there is sufficient free disk space (best-effort, this is checked again before writing)
+	if err = l.checkDiskFree(); err != nil {
+		return
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	var (
+		errs   []error
+		failed int
+	)
+
+	for i, msg := range msgs {
+		// Write message, rotations are handled by each write
+		if err = l.write(newEntry(levelNone, msg)); err == nil {
+			continue
+		}
+
+		if errs == nil {
+			errs = make([]error, len(msgs))
+		}
+
+		errs[i] = err
+		failed++
+	}
+
+	switch failed {
+	case 0:
+		return nil
+	case len(msgs):
+		// The whole batch failed, return the first error
+		return errs[0]
+
+	default:
+		return &BatchError{Errors: errs}
+	}
+}
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLogMany(t *testing.T) {
+	var (
+		l *Logger
+		v *Viewer
+
+		lineCount int
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetNumLines(2)
+
+	if err = l.LogMany([][]byte{[]byte("#1"), []byte("bad\nmessage")}); err != ErrMessageContainsNewline {
+		t.Fatalf("invalid error, expected %v and received %v", ErrMessageContainsNewline, err)
+	}
+
+	if l.count != 0 {
+		t.Fatalf("expected no messages to be written, count is %d", l.count)
+	}
+
+	if err = l.LogMany([][]byte{[]byte("#1"), []byte("#2"), []byte("#3"), []byte("#4"), []byte("#5")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err = NewViewer(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = v.ForEach(func(key string) (err error) {
+		var r *Reader
+		if r, err = NewReader(key); err != nil {
+			return
+		}
+		defer r.Close()
+
+		return r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+			lineCount++
+			return
+		})
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if lineCount != 5 {
+		t.Fatalf("invalid line count, expected %d and received %d", 5, lineCount)
+	}
+}
+
+func TestLogMany_partial(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetAsync(1); err != nil {
+		t.Fatal(err)
+	}
+
+	// The lock is held for the entire batch, so the async loop cannot drain the queue between messages
+	err = l.LogMany([][]byte{[]byte("#1"), []byte("#2")})
+	batchErr, ok := err.(*BatchError)
+	if !ok {
+		t.Fatalf("invalid error, expected *BatchError and received %v", err)
+	}
+
+	if batchErr.Errors[0] != nil || batchErr.Errors[1] != ErrQueueFull {
+		t.Fatalf("invalid batch errors: %v", batchErr.Errors)
+	}
+}
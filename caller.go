@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// unknownCaller is used when the caller cannot be determined
+const unknownCaller = "unknown:0"
+
+var (
+	// pkgPath is the import path of this package (E.g. github.com/gdbu/logger)
+	pkgPath = reflect.TypeOf((*Logger)(nil)).Elem().PkgPath()
+	// methodPrefix is the function name prefix for methods within this package (E.g. github.com/gdbu/logger.(*Logger).Log)
+	methodPrefix = pkgPath + ".(*"
+)
+
+// getCaller will return the file and line of the caller at the provided depth
+// Note: Depth is relative to the first function outside of the logger methods, a depth of 1 is the
+// function which called the logger
+func getCaller(depth int, fullPath bool) (caller string) {
+	pcs := make([]uintptr, 32)
+	// Skip runtime.Callers and getCaller
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, methodPrefix) {
+			// Frame is outside of our methods, decrement depth
+			if depth--; depth == 0 {
+				return formatCaller(frame.File, frame.Line, fullPath)
+			}
+		}
+
+		if !more {
+			// We've run out of frames, return
+			return unknownCaller
+		}
+	}
+}
+
+// formatCaller will format a caller as file:line
+func formatCaller(file string, line int, fullPath bool) (caller string) {
+	if file == "" {
+		return unknownCaller
+	}
+
+	if !fullPath {
+		// Shorten file to the base name
+		file = filepath.Base(file)
+	}
+
+	return file + ":" + strconv.Itoa(line)
+}
+
+// SetCallerDepth will set the stack depth of the caller to include within entries
+// Note: A depth of 1 is the function which called the logger, a depth of 0 disables this feature
+func (l *Logger) SetCallerDepth(depth int) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set caller depth
+	l.callerDepth = depth
+}
+
+// SetCallerFullPath will set whether or not the full path of the caller file is included within entries
+// Note: The base name of the file is used by default
+func (l *Logger) SetCallerFullPath(fullPath bool) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set caller full path
+	l.callerFullPath = fullPath
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCallerDepth(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetCallerDepth(1)
+	_, file, line, _ := runtime.Caller(0)
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Logf("#%d", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetCallerFullPath(true)
+	if err = l.Info([]byte("#3")); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetCallerDepth(1000)
+	if err = l.LogString("#4"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		fmt.Sprintf("caller_test.go:%d@#1", line+1),
+		fmt.Sprintf("caller_test.go:%d@#2", line+5),
+		fmt.Sprintf("INFO @%s:%d@#3", file, line+10),
+		"unknown:0@#4",
+	}
+
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/hatchify/errors"
+)
+
+// checksumExt is the extension of checksum sidecar files (E.g. name.<ts>.log.sha256)
+const checksumExt = ".sha256"
+
+// hashFile will return the hex-encoded SHA-256 hash of the provided file
+func hashFile(filename string) (hash []byte, err error) {
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return
+	}
+
+	sum := h.Sum(nil)
+	hash = make([]byte, hex.EncodedLen(len(sum)))
+	hex.Encode(hash, sum)
+	return
+}
+
+// writeChecksum will write the checksum sidecar file for the provided file
+func writeChecksum(filename string) (err error) {
+	var hash []byte
+	if hash, err = hashFile(filename); err != nil {
+		return
+	}
+
+	return ioutil.WriteFile(filename+checksumExt, append(hash, '\n'), 0644)
+}
+
+// VerifyFile will return whether or not the provided file matches the hash within its checksum sidecar file
+// Note: An error is returned if the sidecar file (E.g. name.<ts>.log.sha256) does not exist
+func VerifyFile(filename string) (ok bool, err error) {
+	var expected []byte
+	if expected, err = ioutil.ReadFile(filename + checksumExt); err != nil {
+		return
+	}
+
+	var hash []byte
+	if hash, err = hashFile(filename); err != nil {
+		return
+	}
+
+	ok = bytes.Equal(bytes.TrimSpace(expected), hash)
+	return
+}
+
+// SetIntegrityCheck will set whether or not a checksum sidecar file is written for each rotated file
+// Note: Once a file has been closed, the hex-encoded SHA-256 hash of the file is written to <filename>.sha256. When
+// compression is enabled, the hash is of the compressed file. Empty files which are removed do not receive a sidecar
+func (l *Logger) SetIntegrityCheck(integrity bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.integrity = integrity
+	return
+}
@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetIntegrityCheck(t *testing.T) {
+	var (
+		l *Logger
+
+		ok bool
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetIntegrityCheck(true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = waitForFile(filename + checksumExt); err != nil {
+		t.Fatal(err)
+	}
+
+	var contents, sidecar []byte
+	if contents, err = ioutil.ReadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if sidecar, err = ioutil.ReadFile(filename + checksumExt); err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256(contents)
+	if expected := hex.EncodeToString(sum[:]); strings.TrimSpace(string(sidecar)) != expected {
+		t.Fatalf("invalid checksum, expected \"%s\" and received \"%s\"", expected, sidecar)
+	}
+
+	if ok, err = VerifyFile(filename); err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("expected file to be verified")
+	}
+
+	// Modify the rotated file
+	if err = ioutil.WriteFile(filename, append(contents, "tampered\n"...), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err = VerifyFile(filename); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Fatal("expected modified file to fail verification")
+	}
+
+	// Rotate the empty current file, which is removed without a sidecar
+	empty := l.f.Name()
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = os.Stat(empty + checksumExt); !os.IsNotExist(err) {
+		t.Fatalf("invalid error, expected %v and received %v", os.ErrNotExist, err)
+	}
+}
+
+func waitForFile(filename string) (err error) {
+	for i := 0; i < 100; i++ {
+		if _, err = os.Stat(filename); err == nil {
+			return
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	return fmt.Errorf("file \"%s\" was not created", filename)
+}
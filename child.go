@@ -0,0 +1,49 @@
+package logger
+
+import "github.com/hatchify/errors"
+
+// Child will return a new Logger within the same directory, named as parentname.subname
+// Note: The child inherits the line limit, rotation interval, buffer size, and separator of the parent. The child is
+// independent of the parent and must be closed separately
+func (l *Logger) Child(subname string) (child *Logger, err error) {
+	// Ensure subname is valid
+	if subname == "" {
+		return nil, ErrInvalidName
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Release lock
+		l.mu.Unlock()
+		// Instance of logger has been closed, return
+		return nil, errors.ErrIsClosed
+	}
+
+	c := newLogger(l.dir, l.name+"."+subname)
+	c.numLines = l.numLines
+	c.bufferSize = l.bufferSize
+	c.sep = l.sep
+	c.discard = l.discard
+	c.filePerm = l.filePerm
+	rotateInterval := l.rotateInterval
+	// Release lock
+	l.mu.Unlock()
+
+	// Set initial logger file
+	if err = c.setFile(rotationReasonNone); err != nil {
+		return
+	}
+
+	if rotateInterval > 0 {
+		// Parent has a rotation interval, set the same interval for the child
+		if err = c.SetRotateInterval(rotateInterval); err != nil {
+			c.Close()
+			return
+		}
+	}
+
+	child = c
+	return
+}
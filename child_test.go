@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hatchify/errors"
+)
+
+func TestChild(t *testing.T) {
+	var (
+		l *Logger
+		c *Logger
+
+		parentFiles []string
+		childFiles  []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetNumLines(2)
+	if err = l.SetSeparator('|'); err != nil {
+		t.Fatal(err)
+	}
+
+	if c, err = l.Child("auth"); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.numLines != 2 || c.sep != '|' || c.name != testName+".auth" {
+		t.Fatalf("invalid child settings: %d, %q, %s", c.numLines, c.sep, c.name)
+	}
+
+	parentFile := l.f.Name()
+	// Rotate the child, which should not affect the parent
+	for i := 0; i < 2; i++ {
+		if err = c.LogString("child"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if l.f.Name() != parentFile {
+		t.Fatal("unexpected rotation of parent")
+	}
+
+	if err = l.LogString("parent"); err != nil {
+		t.Fatal(err)
+	}
+
+	if parentFiles, err = l.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	if childFiles, err = c.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parentFiles) != 1 || len(childFiles) != 2 {
+		t.Fatalf("invalid number of files, parent has %d and child has %d", len(parentFiles), len(childFiles))
+	}
+
+	for _, filename := range childFiles {
+		if !strings.Contains(filename, testName+".auth.") {
+			t.Fatalf("invalid child filename: %s", filename)
+		}
+	}
+
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = l.Child("db"); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+}
@@ -0,0 +1,356 @@
+package logger
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// combinedTimeLayout is the timestamp layout used by the Combined Log Format
+const combinedTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// NewCombinedEntry will return a new Combined Log Format entry for the provided request and response data
+func NewCombinedEntry(r *http.Request, status int, size int64, received time.Time) (e *CombinedEntry) {
+	e = &CombinedEntry{
+		Host:      r.RemoteAddr,
+		Time:      received,
+		Status:    status,
+		Bytes:     size,
+		Referer:   r.Referer(),
+		UserAgent: r.UserAgent(),
+	}
+
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		// Remote address contains a port, only use the host
+		e.Host = host
+	}
+
+	if user, _, ok := r.BasicAuth(); ok {
+		e.AuthUser = user
+	} else if r.URL != nil && r.URL.User != nil {
+		e.AuthUser = r.URL.User.Username()
+	}
+
+	uri := r.RequestURI
+	if uri == "" && r.URL != nil {
+		// Request was not received by a server (E.g. a client request), use the URL
+		uri = r.URL.RequestURI()
+	}
+
+	e.Request = r.Method + " " + uri + " " + r.Proto
+	return
+}
+
+// CombinedEntry represents an entry in the Apache/NGINX Combined Log Format
+// Note: Empty string fields are written as "-"
+type CombinedEntry struct {
+	// Remote host
+	Host string
+	// RFC 1413 identity of the client, this is always empty for entries created by NewCombinedEntry
+	Ident string
+	// Authenticated user
+	AuthUser string
+	// Time the request was received
+	Time time.Time
+	// Request line (E.g. "GET /index.html HTTP/1.1")
+	Request string
+	// Response status code
+	Status int
+	// Size of the response body in bytes
+	Bytes int64
+	// Referer header
+	Referer string
+	// User-Agent header
+	UserAgent string
+}
+
+// String will return the entry formatted in the Combined Log Format
+func (e *CombinedEntry) String() string {
+	return string(e.appendTo(nil))
+}
+
+// appendTo will append the entry formatted in the Combined Log Format to the provided buffer
+func (e *CombinedEntry) appendTo(buf []byte) []byte {
+	buf = appendCombinedField(buf, e.Host)
+	buf = append(buf, ' ')
+	buf = appendCombinedField(buf, e.Ident)
+	buf = append(buf, ' ')
+	buf = appendCombinedField(buf, e.AuthUser)
+	buf = append(buf, " ["...)
+	buf = e.Time.AppendFormat(buf, combinedTimeLayout)
+	buf = append(buf, "] "...)
+	buf = appendCombinedQuoted(buf, e.Request)
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, int64(e.Status), 10)
+	buf = append(buf, ' ')
+	if e.Bytes == 0 {
+		buf = append(buf, '-')
+	} else {
+		buf = strconv.AppendInt(buf, e.Bytes, 10)
+	}
+
+	buf = append(buf, ' ')
+	buf = appendCombinedQuoted(buf, e.Referer)
+	buf = append(buf, ' ')
+	return appendCombinedQuoted(buf, e.UserAgent)
+}
+
+// appendCombinedField will append an unquoted field, empty values are written as "-"
+// Note: Spaces and control characters are escaped so the field cannot be split
+func appendCombinedField(buf []byte, value string) []byte {
+	if value == "" {
+		return append(buf, '-')
+	}
+
+	return appendCombinedEscaped(buf, value, ' ')
+}
+
+// appendCombinedQuoted will append a quoted field, empty values are written as "-"
+func appendCombinedQuoted(buf []byte, value string) []byte {
+	buf = append(buf, '"')
+	if value == "" {
+		buf = append(buf, '-')
+	} else {
+		buf = appendCombinedEscaped(buf, value, '"')
+	}
+
+	return append(buf, '"')
+}
+
+// appendCombinedEscaped will append the value with backslashes, control characters and the provided delimiter escaped
+// as \xHH (matching NGINX)
+func appendCombinedEscaped(buf []byte, value string, delim byte) []byte {
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '\\' || c == delim || c < 0x20 || c == 0x7f {
+			buf = append(buf, fmt.Sprintf("\\x%02X", c)...)
+			continue
+		}
+
+		buf = append(buf, c)
+	}
+
+	return buf
+}
+
+// ParseCombinedLogLine will parse a line written in the Combined Log Format
+// Note: Fields written as "-" are returned empty, escaped characters (\xHH) are unescaped
+func ParseCombinedLogLine(line string) (e *CombinedEntry, err error) {
+	var c CombinedEntry
+	p := combinedParser{line: line}
+	if c.Host, err = p.field(); err != nil {
+		return
+	}
+
+	if c.Ident, err = p.field(); err != nil {
+		return
+	}
+
+	if c.AuthUser, err = p.field(); err != nil {
+		return
+	}
+
+	var ts string
+	if ts, err = p.bracketed(); err != nil {
+		return
+	}
+
+	if c.Time, err = time.Parse(combinedTimeLayout, ts); err != nil {
+		return nil, ErrInvalidCombinedLogLine
+	}
+
+	if c.Request, err = p.quoted(); err != nil {
+		return
+	}
+
+	var value string
+	if value, err = p.field(); err != nil {
+		return
+	}
+
+	if c.Status, err = strconv.Atoi(value); err != nil {
+		return nil, ErrInvalidCombinedLogLine
+	}
+
+	if value, err = p.field(); err != nil {
+		return
+	}
+
+	if value != "" {
+		if c.Bytes, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return nil, ErrInvalidCombinedLogLine
+		}
+	}
+
+	if c.Referer, err = p.quoted(); err != nil {
+		return
+	}
+
+	if c.UserAgent, err = p.quoted(); err != nil {
+		return
+	}
+
+	if p.line != "" {
+		// Unexpected trailing data, return
+		return nil, ErrInvalidCombinedLogLine
+	}
+
+	return &c, nil
+}
+
+// combinedParser will parse the fields of a Combined Log Format line in order
+type combinedParser struct {
+	line string
+}
+
+// field will parse the next unquoted field
+func (p *combinedParser) field() (value string, err error) {
+	if p.line == "" {
+		return "", ErrInvalidCombinedLogLine
+	}
+
+	i := strings.IndexByte(p.line, ' ')
+	if i == -1 {
+		i = len(p.line)
+	}
+
+	value = p.line[:i]
+	p.next(i)
+	return unescapeCombined(value)
+}
+
+// bracketed will parse the next field enclosed within square brackets
+func (p *combinedParser) bracketed() (value string, err error) {
+	if !strings.HasPrefix(p.line, "[") {
+		return "", ErrInvalidCombinedLogLine
+	}
+
+	i := strings.IndexByte(p.line, ']')
+	if i == -1 {
+		return "", ErrInvalidCombinedLogLine
+	}
+
+	value = p.line[1:i]
+	p.next(i + 1)
+	return
+}
+
+// quoted will parse the next field enclosed within double quotes
+func (p *combinedParser) quoted() (value string, err error) {
+	if !strings.HasPrefix(p.line, "\"") {
+		return "", ErrInvalidCombinedLogLine
+	}
+
+	// Quotes within values are always escaped, so the first quote closes the field
+	i := strings.IndexByte(p.line[1:], '"')
+	if i == -1 {
+		return "", ErrInvalidCombinedLogLine
+	}
+
+	value = p.line[1 : i+1]
+	p.next(i + 2)
+	return unescapeCombined(value)
+}
+
+// next will advance past the current field (ending at i) and its following space
+func (p *combinedParser) next(i int) {
+	p.line = p.line[i:]
+	p.line = strings.TrimPrefix(p.line, " ")
+}
+
+// unescapeCombined will unescape \xHH sequences, "-" is returned as an empty value
+func unescapeCombined(value string) (out string, err error) {
+	if value == "-" {
+		return "", nil
+	}
+
+	if strings.IndexByte(value, '\\') == -1 {
+		// Value does not contain any escaped characters, return
+		return value, nil
+	}
+
+	buf := make([]byte, 0, len(value))
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			buf = append(buf, value[i])
+			continue
+		}
+
+		if i+3 >= len(value) || value[i+1] != 'x' {
+			// Escape sequence is incomplete, return
+			return "", ErrInvalidCombinedLogLine
+		}
+
+		var c uint64
+		if c, err = strconv.ParseUint(value[i+2:i+4], 16, 8); err != nil {
+			return "", ErrInvalidCombinedLogLine
+		}
+
+		buf = append(buf, byte(c))
+		i += 3
+	}
+
+	return string(buf), nil
+}
+
+// NewCombinedLogWriter will return a writer which logs HTTP requests to the provided logger in the Combined Log Format
+func NewCombinedLogWriter(l *Logger) *CombinedLogWriter {
+	var c CombinedLogWriter
+	c.l = l
+	return &c
+}
+
+// CombinedLogWriter will log HTTP requests in the Combined Log Format
+type CombinedLogWriter struct {
+	l *Logger
+}
+
+// Log will log a request with the provided response data
+func (c *CombinedLogWriter) Log(r *http.Request, status int, size int64, received time.Time) (err error) {
+	return c.l.Log(NewCombinedEntry(r, status, size, received).appendTo(nil))
+}
+
+// Handler will return an http.Handler which logs each request served by the provided handler
+// Note: Logging errors are passed to the logger's error handler
+func (c *CombinedLogWriter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received := time.Now()
+		rw := combinedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(&rw, r)
+
+		if err := c.Log(r, rw.status, rw.size, received); err != nil {
+			c.l.handleError(fmt.Errorf("error logging request: %v", err))
+		}
+	})
+}
+
+// combinedResponseWriter will record the status and size of a response
+type combinedResponseWriter struct {
+	http.ResponseWriter
+
+	status int
+	size   int64
+	// Whether or not the header has been written
+	wroteHeader bool
+}
+
+// WriteHeader will record the status code and pass it to the underlying response writer
+func (w *combinedResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write will record the number of bytes written and pass them to the underlying response writer
+func (w *combinedResponseWriter) Write(bs []byte) (n int, err error) {
+	w.wroteHeader = true
+	n, err = w.ResponseWriter.Write(bs)
+	w.size += int64(n)
+	return
+}
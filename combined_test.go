@@ -0,0 +1,188 @@
+package logger
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCombinedLogWriter(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		expected []*CombinedEntry
+		err      error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCombinedLogWriter(l)
+	received := time.Date(2021, time.March, 4, 15, 4, 5, 0, time.FixedZone("", -7*60*60))
+	for i := 0; i < 10; i++ {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/items/%d?q=a+b", i), nil)
+		req.RemoteAddr = fmt.Sprintf("10.0.0.%d:5000", i)
+		req.Header.Set("User-Agent", fmt.Sprintf("agent/%d (\"quoted\" \\ %d)", i, i))
+		if i%2 == 0 {
+			req.Header.Set("Referer", fmt.Sprintf("https://example.com/%d", i))
+			req.SetBasicAuth(fmt.Sprintf("user%d", i), "password")
+		}
+
+		status := http.StatusOK + i
+		size := int64(i * 100)
+		if err = c.Log(req, status, size, received.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatal(err)
+		}
+
+		expected = append(expected, NewCombinedEntry(req, status, size, received.Add(time.Duration(i)*time.Second)))
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var i int
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		var e *CombinedEntry
+		if e, err = ParseCombinedLogLine(string(log)); err != nil {
+			return
+		}
+
+		if err = testCombinedEntry(expected[i], e); err != nil {
+			return fmt.Errorf("entry %d: %v", i, err)
+		}
+
+		i++
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if i != len(expected) {
+		t.Fatalf("invalid number of entries, expected %d and received %d", len(expected), i)
+	}
+}
+
+func TestCombinedLogWriter_Handler(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+		e *CombinedEntry
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewCombinedLogWriter(l).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("not found"))
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/missing", nil))
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var entry Entry
+	if entry, err = r.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	if e, err = ParseCombinedLogLine(entry.Message); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Request != "POST /missing HTTP/1.1" {
+		t.Fatalf("invalid request, expected \"%s\" and received \"%s\"", "POST /missing HTTP/1.1", e.Request)
+	}
+
+	if e.Status != http.StatusNotFound {
+		t.Fatalf("invalid status, expected %d and received %d", http.StatusNotFound, e.Status)
+	}
+
+	if e.Bytes != 9 {
+		t.Fatalf("invalid bytes, expected %d and received %d", 9, e.Bytes)
+	}
+}
+
+func TestParseCombinedLogLine(t *testing.T) {
+	line := `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 ` +
+		`"http://www.example.com/start.html" "Mozilla/4.08 [en] (Win98; I ;Nav)"`
+
+	e, err := ParseCombinedLogLine(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.String() != line {
+		t.Fatalf("invalid line, expected \"%s\" and received \"%s\"", line, e.String())
+	}
+
+	for _, invalid := range []string{
+		"",
+		`127.0.0.1 - frank 10/Oct/2000:13:55:36 -0700 "GET / HTTP/1.0" 200 2326 "-" "-"`,
+		`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0 200 2326 "-" "-"`,
+		`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0" 200 2326 "-" "\x2"`,
+		`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.0" 200 2326 "-" "-" extra`,
+	} {
+		if _, err = ParseCombinedLogLine(invalid); err != ErrInvalidCombinedLogLine {
+			t.Fatalf("invalid error, expected %v and received %v", ErrInvalidCombinedLogLine, err)
+		}
+	}
+}
+
+func testCombinedEntry(expected, e *CombinedEntry) (err error) {
+	switch {
+	case e.Host != expected.Host:
+		return fmt.Errorf("invalid host, expected \"%s\" and received \"%s\"", expected.Host, e.Host)
+	case e.Ident != expected.Ident:
+		return fmt.Errorf("invalid ident, expected \"%s\" and received \"%s\"", expected.Ident, e.Ident)
+	case e.AuthUser != expected.AuthUser:
+		return fmt.Errorf("invalid auth user, expected \"%s\" and received \"%s\"", expected.AuthUser, e.AuthUser)
+	case !e.Time.Equal(expected.Time):
+		return fmt.Errorf("invalid time, expected %v and received %v", expected.Time, e.Time)
+	case e.Request != expected.Request:
+		return fmt.Errorf("invalid request, expected \"%s\" and received \"%s\"", expected.Request, e.Request)
+	case e.Status != expected.Status:
+		return fmt.Errorf("invalid status, expected %d and received %d", expected.Status, e.Status)
+	case e.Bytes != expected.Bytes:
+		return fmt.Errorf("invalid bytes, expected %d and received %d", expected.Bytes, e.Bytes)
+	case e.Referer != expected.Referer:
+		return fmt.Errorf("invalid referer, expected \"%s\" and received \"%s\"", expected.Referer, e.Referer)
+	case e.UserAgent != expected.UserAgent:
+		return fmt.Errorf("invalid user agent, expected \"%s\" and received \"%s\"", expected.UserAgent, e.UserAgent)
+	}
+
+	return
+}
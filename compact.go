@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"os"
+
+	"github.com/hatchify/errors"
+)
+
+// OpenCompact will open a log file written in compact mode for reading
+// Note: Entries returned by Next have a zero timestamp and the raw line as their message, lines passed to ForEach have
+// a zero timestamp
+func OpenCompact(filename string) (rp *Reader, err error) {
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+
+	rp = newReader(f)
+	rp.compact = true
+	return
+}
+
+// SetCompactMode will set whether or not entries are written as the raw message without a timestamp
+// Note: Each entry is written as the prefix and message followed by a newline, omitting the timestamp, level and other
+// metadata. This is intended for use cases where timestamps are added externally (E.g. Kafka record timestamps). Use
+// OpenCompact to read files written in compact mode
+func (l *Logger) SetCompactMode(compact bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	if compact {
+		// Set format to compact
+		l.format = formatCompact
+	} else if l.format == formatCompact {
+		// Compact mode is being disabled, revert to the default format
+		l.format = formatText
+	}
+
+	return
+}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+func TestSetCompactMode(t *testing.T) {
+	var (
+		normal  *Logger
+		compact *Logger
+		err     error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if normal, err = NewWithOptions(testDir, testName+"_normal", WithNumLines(10000)); err != nil {
+		t.Fatal(err)
+	}
+
+	if compact, err = NewWithOptions(testDir, testName+"_compact", WithNumLines(10000)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = compact.SetCompactMode(true); err != nil {
+		t.Fatal(err)
+	}
+
+	normalFilename, compactFilename := normal.f.Name(), compact.f.Name()
+	for i := 0; i < 10000; i++ {
+		msg := fmt.Sprintf("event #%d", i+1)
+		if err = normal.LogString(msg); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = compact.LogString(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = normal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = compact.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = compact.SetCompactMode(false); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+
+	var normalInfo, compactInfo os.FileInfo
+	if normalInfo, err = os.Stat(normalFilename); err != nil {
+		t.Fatal(err)
+	}
+
+	if compactInfo, err = os.Stat(compactFilename); err != nil {
+		t.Fatal(err)
+	}
+
+	if max := normalInfo.Size() * 8 / 10; compactInfo.Size() > max {
+		t.Fatalf("invalid file size, expected at most %d and received %d", max, compactInfo.Size())
+	}
+
+	var r *Reader
+	if r, err = OpenCompact(compactFilename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var e Entry
+	if e, err = r.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Timestamp != 0 {
+		t.Fatalf("invalid timestamp, expected %d and received %d", 0, e.Timestamp)
+	}
+
+	if e.Message != "event #1" {
+		t.Fatalf("invalid message, expected %s and received %s", "event #1", e.Message)
+	}
+
+	var count int
+	if err = r.ForEach(0, func(ts time.Time, log []byte) error {
+		count++
+		if !ts.IsZero() {
+			return fmt.Errorf("invalid timestamp, expected zero and received %v", ts)
+		}
+
+		if expected := fmt.Sprintf("event #%d", count); string(log) != expected {
+			return fmt.Errorf("invalid message, expected %s and received %s", expected, log)
+		}
+
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 10000 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 10000, count)
+	}
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+)
+
+// compressedExt is the extension appended to compressed log files
+const compressedExt = ".gz"
+
+// compressFile will gzip compress the provided file, replacing it with a .gz file
+// Note: If compression fails, the original file is left untouched
+func compressFile(filename string) (compressed string, err error) {
+	compressed = filename + compressedExt
+
+	var src *os.File
+	if src, err = os.Open(filename); err != nil {
+		return
+	}
+	defer src.Close()
+
+	var info os.FileInfo
+	if info, err = src.Stat(); err != nil {
+		return
+	}
+
+	var dst *os.File
+	// Create the compressed file with the same permissions as the original
+	if dst, err = os.OpenFile(compressed, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm()); err != nil {
+		return
+	}
+
+	if err = writeCompressed(dst, src); err != nil {
+		// We encountered an error while compressing, remove the partial compressed file
+		dst.Close()
+		os.Remove(compressed)
+		return
+	}
+
+	if err = dst.Close(); err != nil {
+		os.Remove(compressed)
+		return
+	}
+
+	// Compressed file is complete, remove the original
+	err = os.Remove(filename)
+	return
+}
+
+// writeCompressed will gzip the contents of src into dst and sync dst
+func writeCompressed(dst *os.File, src io.Reader) (err error) {
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		return
+	}
+
+	if err = gz.Close(); err != nil {
+		return
+	}
+
+	return dst.Sync()
+}
+
+// processRotated will compress (if enabled) and write the checksum (if enabled) of a rotated file, then call the on
+// rotate func (if set) with the resulting filename
+// Note: This is intended to be called within a goroutine and must not hold the Logger mutex
+func processRotated(filename string, compress, integrity bool, onRotate RotateFn, onError ErrorFn) {
+	if compress {
+		compressed, err := compressFile(filename)
+		if err == nil {
+			filename = compressed
+		} else {
+			// Compression failed, the original file is still in place
+			onError(fmt.Errorf("error compressing file: %v", err))
+		}
+	}
+
+	if integrity {
+		// Write checksum of the resulting file
+		if err := writeChecksum(filename); err != nil {
+			onError(fmt.Errorf("error writing checksum: %v", err))
+		}
+	}
+
+	if onRotate != nil {
+		onRotate(filename)
+	}
+}
@@ -0,0 +1,134 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCompressOnRotate(t *testing.T) {
+	var (
+		l *Logger
+
+		filenames []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetNumLines(1)
+	l.SetCompressOnRotate(true)
+
+	for _, log := range []string{"#1", "#2"} {
+		filenames = append(filenames, l.f.Name())
+		if err = l.LogString(log); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, filename := range filenames {
+		if err = waitForRemoval(filename); err != nil {
+			t.Fatal(err)
+		}
+
+		var bs []byte
+		if bs, err = readCompressed(filename + compressedExt); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, log, err := parseLine(bs[:len(bs)-1]); err != nil || len(log) == 0 {
+			t.Fatalf("invalid compressed contents: \"%s\"", string(bs))
+		}
+	}
+}
+
+func TestCompressOnRotate_error(t *testing.T) {
+	var (
+		l *Logger
+
+		errC = make(chan error, 1)
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetCompressOnRotate(true)
+	l.SetErrorHandler(func(err error) {
+		errC <- err
+	})
+
+	filename := l.f.Name()
+	// Create the compressed file ahead of time so compression fails
+	if err = ioutil.WriteFile(filename+compressedExt, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-errC:
+	case <-time.After(time.Second):
+		t.Fatal("expected error handler to be called")
+	}
+
+	if _, err = os.Stat(filename); err != nil {
+		t.Fatalf("expected original file to remain: %v", err)
+	}
+}
+
+func waitForRemoval(filename string) (err error) {
+	for i := 0; i < 100; i++ {
+		if _, err = os.Stat(filename); os.IsNotExist(err) {
+			return nil
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	return fmt.Errorf("file \"%s\" was not removed", filename)
+}
+
+func readCompressed(filename string) (bs []byte, err error) {
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+	defer f.Close()
+
+	var gz *gzip.Reader
+	if gz, err = gzip.NewReader(f); err != nil {
+		return
+	}
+	defer gz.Close()
+
+	return ioutil.ReadAll(gz)
+}
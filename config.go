@@ -0,0 +1,286 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config represents the configuration of a Logger, intended to be decoded from configuration files
+// Note: Zero values leave the corresponding setting at its default
+type Config struct {
+	// Log directory (required)
+	Dir string `json:"dir" yaml:"dir"`
+	// Log name (required)
+	Name string `json:"name" yaml:"name"`
+
+	// Number of lines before rotation
+	NumLines int `json:"numLines" yaml:"numLines"`
+	// Number of bytes before rotation
+	MaxBytes int64 `json:"maxBytes" yaml:"maxBytes"`
+	// Duration before rotation
+	RotateInterval time.Duration `json:"rotateInterval" yaml:"rotateInterval"`
+	// Rotate at local midnight and use date based filenames
+	RotateAtMidnight bool `json:"rotateAtMidnight" yaml:"rotateAtMidnight"`
+	// Rotate at the start of each local hour and use date and hour based filenames
+	RotateHourly bool `json:"rotateHourly" yaml:"rotateHourly"`
+
+	// Minimum level of messages to write (debug, info, warn, error or fatal), short forms (E.g. dbg) are accepted
+	Level string `json:"level" yaml:"level"`
+	// Format of log entries (text, json, logfmt, csv or msgpack)
+	Format string `json:"format" yaml:"format"`
+	// Time layout used for timestamps
+	TimestampFormat string `json:"timestampFormat" yaml:"timestampFormat"`
+	// Include the hostname within entries
+	IncludeHostname bool `json:"includeHostname" yaml:"includeHostname"`
+	// Include the process ID within entries
+	IncludePID bool `json:"includePID" yaml:"includePID"`
+
+	// Size of the write buffer (in bytes)
+	BufferSize int `json:"bufferSize" yaml:"bufferSize"`
+	// Interval between periodic flushes
+	FlushInterval time.Duration `json:"flushInterval" yaml:"flushInterval"`
+
+	// Compress files after they are rotated
+	CompressOnRotate bool `json:"compressOnRotate" yaml:"compressOnRotate"`
+	// Number of rotated files to retain
+	RetainCount int `json:"retainCount" yaml:"retainCount"`
+	// Duration to retain rotated files
+	RetainDuration time.Duration `json:"retainDuration" yaml:"retainDuration"`
+	// Maintain a symlink which points to the current file
+	LatestSymlink bool `json:"latestSymlink" yaml:"latestSymlink"`
+}
+
+// Validate will ensure the config is valid
+func (c *Config) Validate() (err error) {
+	if len(c.Dir) == 0 {
+		return ErrInvalidDir
+	}
+
+	if len(c.Name) == 0 {
+		return ErrInvalidName
+	}
+
+	if len(c.Level) > 0 {
+		if _, ok := levelFromName(c.Level); !ok {
+			return fmt.Errorf("invalid level \"%s\": %v", c.Level, ErrInvalidLevel)
+		}
+	}
+
+	switch c.Format {
+	case "", "text", "json", "logfmt", "csv", "msgpack":
+	default:
+		return fmt.Errorf("invalid format \"%s\": %v", c.Format, ErrInvalidFormat)
+	}
+
+	return
+}
+
+// options will return the options which apply the config
+// Note: The config is expected to have been validated
+func (c *Config) options() (opts []Option) {
+	if c.NumLines > 0 {
+		opts = append(opts, WithNumLines(c.NumLines))
+	}
+
+	if c.MaxBytes > 0 {
+		opts = append(opts, WithMaxBytes(c.MaxBytes))
+	}
+
+	if c.RotateInterval > 0 {
+		opts = append(opts, WithRotateInterval(c.RotateInterval))
+	}
+
+	if c.RotateAtMidnight {
+		opts = append(opts, WithRotateAtMidnight(true))
+	}
+
+	if c.RotateHourly {
+		opts = append(opts, WithRotateHourly(true))
+	}
+
+	if level, ok := levelFromName(c.Level); ok {
+		opts = append(opts, WithLevel(level))
+	}
+
+	switch c.Format {
+	case "json":
+		opts = append(opts, WithJSON(true))
+	case "logfmt":
+		opts = append(opts, WithLogfmt(true))
+	case "csv":
+		opts = append(opts, WithCSV(true))
+	case "msgpack":
+		opts = append(opts, WithMessagePack(true))
+	}
+
+	if len(c.TimestampFormat) > 0 {
+		opts = append(opts, WithTimestampFormat(c.TimestampFormat))
+	}
+
+	if c.IncludeHostname {
+		opts = append(opts, WithIncludeHostname(true))
+	}
+
+	if c.IncludePID {
+		opts = append(opts, WithIncludePID(true))
+	}
+
+	if c.BufferSize > 0 {
+		opts = append(opts, WithBufferSize(c.BufferSize))
+	}
+
+	if c.FlushInterval > 0 {
+		opts = append(opts, WithFlushInterval(c.FlushInterval))
+	}
+
+	if c.CompressOnRotate {
+		opts = append(opts, WithCompressOnRotate(true))
+	}
+
+	if c.RetainCount > 0 {
+		opts = append(opts, WithRetainCount(c.RetainCount))
+	}
+
+	if c.RetainDuration > 0 {
+		opts = append(opts, WithRetainDuration(c.RetainDuration))
+	}
+
+	if c.LatestSymlink {
+		opts = append(opts, WithLatestSymlink(true))
+	}
+
+	return
+}
+
+// NewFromConfig will return a new instance of Logger configured by the provided config
+func NewFromConfig(cfg Config) (lp *Logger, err error) {
+	if err = cfg.Validate(); err != nil {
+		return
+	}
+
+	return NewWithOptions(cfg.Dir, cfg.Name, cfg.options()...)
+}
+
+// ParseConfigFromEnv will parse a config from environment variables with the provided prefix
+// Note: Variables are named by the prefix and the upper snake case field name, E.g. LOGGER_DIR, LOGGER_NUM_LINES and
+// LOGGER_ROTATE_INTERVAL for a prefix of "LOGGER". Durations are parsed with time.ParseDuration (E.g. "1h30m")
+func ParseConfigFromEnv(prefix string) (cfg Config, err error) {
+	e := envParser{prefix: prefix}
+	cfg.Dir = e.string("DIR")
+	cfg.Name = e.string("NAME")
+	cfg.NumLines = e.int("NUM_LINES")
+	cfg.MaxBytes = e.int64("MAX_BYTES")
+	cfg.RotateInterval = e.duration("ROTATE_INTERVAL")
+	cfg.RotateAtMidnight = e.bool("ROTATE_AT_MIDNIGHT")
+	cfg.RotateHourly = e.bool("ROTATE_HOURLY")
+	cfg.Level = e.string("LEVEL")
+	cfg.Format = e.string("FORMAT")
+	cfg.TimestampFormat = e.string("TIMESTAMP_FORMAT")
+	cfg.IncludeHostname = e.bool("INCLUDE_HOSTNAME")
+	cfg.IncludePID = e.bool("INCLUDE_PID")
+	cfg.BufferSize = e.int("BUFFER_SIZE")
+	cfg.FlushInterval = e.duration("FLUSH_INTERVAL")
+	cfg.CompressOnRotate = e.bool("COMPRESS_ON_ROTATE")
+	cfg.RetainCount = e.int("RETAIN_COUNT")
+	cfg.RetainDuration = e.duration("RETAIN_DURATION")
+	cfg.LatestSymlink = e.bool("LATEST_SYMLINK")
+
+	if err = e.err; err != nil {
+		return
+	}
+
+	err = cfg.Validate()
+	return
+}
+
+// envParser parses environment variables, retaining the first error encountered
+type envParser struct {
+	prefix string
+	err    error
+}
+
+// lookup will return the value of the environment variable for the provided key
+func (e *envParser) lookup(key string) (name, value string) {
+	name = key
+	if len(e.prefix) > 0 {
+		name = e.prefix + "_" + key
+	}
+
+	value = os.Getenv(name)
+	return
+}
+
+// setErr will set the parse error for the provided variable, if an error has not already been set
+func (e *envParser) setErr(name, value string, err error) {
+	if e.err == nil {
+		e.err = fmt.Errorf("invalid value \"%s\" for %s: %v", value, name, err)
+	}
+}
+
+// string will return the environment variable for the provided key as a string
+func (e *envParser) string(key string) (value string) {
+	_, value = e.lookup(key)
+	return
+}
+
+// int will return the environment variable for the provided key as a integer
+func (e *envParser) int(key string) (n int) {
+	name, value := e.lookup(key)
+	if len(value) == 0 {
+		return
+	}
+
+	var err error
+	if n, err = strconv.Atoi(value); err != nil {
+		e.setErr(name, value, err)
+	}
+
+	return
+}
+
+// int64 will return the environment variable for the provided key as a 64-bit integer
+func (e *envParser) int64(key string) (n int64) {
+	name, value := e.lookup(key)
+	if len(value) == 0 {
+		return
+	}
+
+	var err error
+	if n, err = strconv.ParseInt(value, 10, 64); err != nil {
+		e.setErr(name, value, err)
+	}
+
+	return
+}
+
+// bool will return the environment variable for the provided key as a boolean
+func (e *envParser) bool(key string) (b bool) {
+	name, value := e.lookup(key)
+	if len(value) == 0 {
+		return
+	}
+
+	var err error
+	if b, err = strconv.ParseBool(value); err != nil {
+		e.setErr(name, value, err)
+	}
+
+	return
+}
+
+// duration will return the environment variable for the provided key as a duration
+func (e *envParser) duration(key string) (d time.Duration) {
+	name, value := e.lookup(key)
+	if len(value) == 0 {
+		return
+	}
+
+	var err error
+	if d, err = time.ParseDuration(value); err != nil {
+		e.setErr(name, value, err)
+	}
+
+	return
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewFromConfig(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	cfg := Config{
+		Dir:            testDir,
+		Name:           testName,
+		NumLines:       10,
+		RotateInterval: time.Hour,
+		Level:          "WARN",
+		Format:         "json",
+	}
+
+	if l, err = NewFromConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if l.numLines != 10 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 10, l.numLines)
+	}
+
+	if l.rotateInterval != time.Hour {
+		t.Fatalf("invalid rotate interval, expected %v and received %v", time.Hour, l.rotateInterval)
+	}
+
+	if l.level != LevelWarn {
+		t.Fatalf("invalid level, expected %d and received %d", LevelWarn, l.level)
+	}
+
+	if l.format != formatJSON {
+		t.Fatalf("invalid format, expected %d and received %d", formatJSON, l.format)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		cfg      Config
+		expected string
+	}{
+		{Config{Name: testName}, ErrInvalidDir.Error()},
+		{Config{Dir: testDir}, ErrInvalidName.Error()},
+		{Config{Dir: testDir, Name: testName, Level: "verbose"}, "invalid level \"verbose\""},
+		{Config{Dir: testDir, Name: testName, Format: "xml"}, "invalid format \"xml\""},
+	}
+
+	for _, test := range tests {
+		err := test.cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Fatalf("invalid error, expected \"%s\" and received %v", test.expected, err)
+		}
+	}
+}
+
+func TestParseConfigFromEnv(t *testing.T) {
+	var (
+		cfg Config
+
+		err error
+	)
+
+	env := map[string]string{
+		"TEST_LOGGER_DIR":             testDir,
+		"TEST_LOGGER_NAME":            testName,
+		"TEST_LOGGER_NUM_LINES":       "100",
+		"TEST_LOGGER_ROTATE_INTERVAL": "1h30m",
+		"TEST_LOGGER_LEVEL":           "error",
+		"TEST_LOGGER_INCLUDE_PID":     "true",
+	}
+
+	for key, value := range env {
+		os.Setenv(key, value)
+		defer os.Unsetenv(key)
+	}
+
+	if cfg, err = ParseConfigFromEnv("TEST_LOGGER"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Config{
+		Dir:            testDir,
+		Name:           testName,
+		NumLines:       100,
+		RotateInterval: time.Minute * 90,
+		Level:          "error",
+		IncludePID:     true,
+	}
+
+	if cfg != expected {
+		t.Fatalf("invalid config, expected %+v and received %+v", expected, cfg)
+	}
+
+	os.Setenv("TEST_LOGGER_NUM_LINES", "many")
+	if _, err = ParseConfigFromEnv("TEST_LOGGER"); err == nil || !strings.Contains(err.Error(), "TEST_LOGGER_NUM_LINES") {
+		t.Fatalf("invalid error, expected error for %s and received %v", "TEST_LOGGER_NUM_LINES", err)
+	}
+}
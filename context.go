@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+)
+
+// contextExtractor represents a context key whose value is included as a field
+type contextExtractor struct {
+	key       interface{}
+	fieldName string
+}
+
+// extractContextFields will return the fields of the registered context keys which are set within the provided context
+// Note: This is called without holding the logger lock
+func (l *Logger) extractContextFields(ctx context.Context) (fields []field) {
+	// Acquire extractors read lock
+	l.extractorsMu.RLock()
+	extractors := l.extractors
+	// Release extractors read lock, values are extracted without holding a lock
+	l.extractorsMu.RUnlock()
+
+	for _, ex := range extractors {
+		value := ctx.Value(ex.key)
+		if value == nil {
+			// Key is not set within the context, continue
+			continue
+		}
+
+		str, ok := value.(string)
+		if !ok {
+			str = fmt.Sprint(value)
+		}
+
+		fields = append(fields, field{key: ex.fieldName, value: str})
+	}
+
+	return
+}
+
+// AddContextExtractor will register a context key whose value (when set) is included as a field of entries logged with
+// LogContext, using the provided field name
+// Note: Values which are not strings are formatted with fmt.Sprint. Context fields follow (and replace any matching)
+// fields added with AddField, and are only included within JSON and logfmt entries
+func (l *Logger) AddContextExtractor(key interface{}, fieldName string) (err error) {
+	// Ensure field name is valid
+	if !isValidFieldKey(fieldName) {
+		return ErrInvalidFieldKey
+	}
+
+	// Acquire extractors lock
+	l.extractorsMu.Lock()
+	// Defer the release of our extractors lock
+	defer l.extractorsMu.Unlock()
+
+	// Copy extractors, so extractions in progress are unaffected
+	extractors := make([]contextExtractor, 0, len(l.extractors)+1)
+	extractors = append(extractors, l.extractors...)
+	l.extractors = append(extractors, contextExtractor{key: key, fieldName: fieldName})
+	return
+}
+
+// lockContext will acquire the lock, returning early if the context is done before the lock is acquired
+func (l *Logger) lockContext(ctx context.Context) (err error) {
+	locked := make(chan struct{})
+	go func() {
+		// Acquire lock
+		l.mu.Lock()
+		// Signal that the lock has been acquired
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		return
+	case <-ctx.Done():
+		// Context is done before we acquired the lock, ensure the lock is released once it's acquired
+		go func() {
+			<-locked
+			l.mu.Unlock()
+		}()
+
+		return ctx.Err()
+	}
+}
+
+// LogContext will log a message, returning early if the context is done before the message is written
+func (l *Logger) LogContext(ctx context.Context, msg []byte) (err error) {
+	// Ensure the context is not already done
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	// Truncate message to the max line length (if set)
+	msg = l.truncateMessage(msg)
+	// Ensure the message is valid before acquiring lock
+	if err = l.validateMessage(msg); err != nil {
+		return
+	}
+
+	// Ensure there is sufficient free disk space (best-effort, this is checked again before writing)
+	if err = l.checkDiskFree(); err != nil {
+		return
+	}
+
+	e := newEntry(levelNone, msg)
+	// Extract fields from the context (if any extractors are registered) before acquiring lock
+	e.ctxFields = l.extractContextFields(ctx)
+
+	// Acquire lock, respecting the context
+	if err = l.lockContext(ctx); err != nil {
+		return
+	}
+	// Defer the release of our lock
+	defer l.unlock()
+
+	// Write message
+	return l.write(e)
+}
+
+// LogStringContext will log a string message, returning early if the context is done before the message is written
+func (l *Logger) LogStringContext(ctx context.Context, msg string) (err error) {
+	// Convert message to bytes and pass to l.LogContext
+	return l.LogContext(ctx, []byte(msg))
+}
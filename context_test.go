@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLogContext(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.LogStringContext(context.Background(), "#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.count != 1 {
+		t.Fatalf("invalid count, expected %d and received %d", 1, l.count)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err = l.LogStringContext(ctx, "#2"); err != context.Canceled {
+		t.Fatalf("invalid error, expected %v and received %v", context.Canceled, err)
+	}
+
+	// Hold the lock to simulate a blocked write
+	l.mu.Lock()
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+
+	if err = l.LogStringContext(ctx, "#3"); err != context.DeadlineExceeded {
+		t.Fatalf("invalid error, expected %v and received %v", context.DeadlineExceeded, err)
+	}
+
+	l.mu.Unlock()
+
+	if err = l.LogString("#4"); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.count != 2 {
+		t.Fatalf("invalid count, expected %d and received %d", 2, l.count)
+	}
+}
+
+func TestAddContextExtractor(t *testing.T) {
+	type contextKey string
+
+	var err error
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	ctx := context.WithValue(context.Background(), contextKey("userID"), "user-1")
+	ctx = context.WithValue(ctx, contextKey("tenantID"), 42)
+
+	for _, opt := range []Option{WithJSON(true), WithLogfmt(true)} {
+		var l *Logger
+		if l, err = NewWithOptions(testDir, testName, opt, WithField("service", "api")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = l.AddContextExtractor(contextKey("userID"), "user_id"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = l.AddContextExtractor(contextKey("tenantID"), "tenant_id"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = l.AddContextExtractor(contextKey("requestID"), "request id"); err != ErrInvalidFieldKey {
+			t.Fatalf("invalid error, expected %v and received %v", ErrInvalidFieldKey, err)
+		}
+
+		if err = l.LogStringContext(ctx, "hello"); err != nil {
+			t.Fatal(err)
+		}
+
+		// Context fields are only included for entries logged with LogContext
+		if err = l.LogString("world"); err != nil {
+			t.Fatal(err)
+		}
+
+		filename := l.f.Name()
+		if err = l.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		var r *Reader
+		if r, err = NewReader(filename); err != nil {
+			t.Fatal(err)
+		}
+
+		var e Entry
+		if e, err = r.Next(); err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Extra["user_id"] != "user-1" || e.Extra["tenant_id"] != "42" || e.Extra["service"] != "api" {
+			t.Fatalf("invalid fields, expected user_id, tenant_id and service and received %v", e.Extra)
+		}
+
+		if e, err = r.Next(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := e.Extra["user_id"]; ok {
+			t.Fatalf("invalid fields, expected no user_id and received %v", e.Extra)
+		}
+
+		r.Close()
+		os.RemoveAll(testDir)
+	}
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/csv"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+// csvHeader is the header of the core CSV columns, field keys follow as additional columns
+var csvHeader = []string{"timestamp", "level", "message"}
+
+// appendCSVEntry will append the entry as a CSV row to the provided buffer
+// Note: Rows are formatted as timestamp (RFC3339),level,message followed by the value of each field. Hostname, PID,
+// caller and stack are not included
+func appendCSVEntry(buf []byte, e entry) (out []byte, err error) {
+	record := make([]string, 0, len(csvHeader)+len(e.fields))
+	record = append(record, time.Now().Format(time.RFC3339))
+	record = append(record, e.level.name())
+	record = append(record, string(e.prefix)+string(e.msg))
+	for _, f := range e.fields {
+		record = append(record, f.value)
+	}
+
+	return appendCSVRecord(buf, record)
+}
+
+// appendCSVHeader will append the CSV header row, including a column for each field, to the provided buffer
+func appendCSVHeader(buf []byte, fields []field) (out []byte, err error) {
+	record := make([]string, 0, len(csvHeader)+len(fields))
+	record = append(record, csvHeader...)
+	for _, f := range fields {
+		record = append(record, f.key)
+	}
+
+	return appendCSVRecord(buf, record)
+}
+
+// appendCSVRecord will append the record as a CSV row (without a trailing newline) to the provided buffer
+func appendCSVRecord(buf []byte, record []string) (out []byte, err error) {
+	w := bytes.NewBuffer(buf)
+	cw := csv.NewWriter(w)
+	if err = cw.Write(record); err != nil {
+		return
+	}
+
+	cw.Flush()
+	if err = cw.Error(); err != nil {
+		return
+	}
+
+	// Trim the newline which follows the row, entries are followed by a newline when written
+	out = bytes.TrimSuffix(w.Bytes(), newline)
+	return
+}
+
+// needsCSVHeader will return whether or not the CSV header row should precede the next entry
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) needsCSVHeader() bool {
+	// The header is written before the first entry of a new file, so files never contain only a header
+	return l.count == 0 && l.size == 0 && l.f != nil && isEmptyFile(l.f)
+}
+
+// UseCSV will set whether or not entries are written as CSV rows
+// Note: Each file begins with a header row (timestamp,level,message followed by the keys of any fields set at the time
+// the file receives its first entry). Fields added later are appended as additional columns without a header. CSV
+// files cannot be parsed by Reader.Next, use encoding/csv instead
+func (l *Logger) UseCSV(useCSV bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	if useCSV {
+		// Set format to CSV
+		l.format = formatCSV
+	} else if l.format == formatCSV {
+		// CSV is being disabled, revert to the default format
+		l.format = formatText
+	}
+
+	return
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+func TestUseCSV(t *testing.T) {
+	var (
+		l     *Logger
+		files []string
+		err   error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = NewWithOptions(testDir, testName, WithCSV(true), WithNumLines(60)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.AddField("service", "api"); err != nil {
+		t.Fatal(err)
+	}
+
+	escaped := `hello, "world"`
+	for i := 0; i < 100; i++ {
+		msg := fmt.Sprintf("#%d", i+1)
+		if i == 10 {
+			msg = escaped
+		}
+
+		if err = l.Info([]byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if files, err = l.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.UseCSV(false); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("invalid number of files, expected %d and received %d", 2, len(files))
+	}
+
+	var rows [][]string
+	for i, filename := range files {
+		var records [][]string
+		if records, err = testReadCSV(filename); err != nil {
+			t.Fatal(err)
+		}
+
+		// Each file begins with a header row
+		if expected := []int{61, 41}[i]; len(records) != expected {
+			t.Fatalf("invalid number of rows, expected %d and received %d", expected, len(records))
+		}
+
+		header := fmt.Sprint(records[0])
+		if expected := fmt.Sprint([]string{"timestamp", "level", "message", "service"}); header != expected {
+			t.Fatalf("invalid header, expected %s and received %s", expected, header)
+		}
+
+		rows = append(rows, records[1:]...)
+	}
+
+	for i, row := range rows {
+		if len(row) != 4 {
+			t.Fatalf("invalid number of columns, expected %d and received %d", 4, len(row))
+		}
+
+		if _, err = time.Parse(time.RFC3339, row[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := fmt.Sprintf("#%d", i+1)
+		if i == 10 {
+			expected = escaped
+		}
+
+		if row[1] != LevelInfo.name() || row[2] != expected || row[3] != "api" {
+			t.Fatalf("invalid row, expected [INFO %s api] and received %v", expected, row[1:])
+		}
+	}
+}
+
+func testReadCSV(filename string) (records [][]string, err error) {
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+	defer f.Close()
+
+	return csv.NewReader(f).ReadAll()
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// DebugState represents a snapshot of the state of a logger
+type DebugState struct {
+	Dir  string `json:"dir"`
+	Name string `json:"name"`
+
+	NumLines       int           `json:"numLines"`
+	RotateInterval time.Duration `json:"rotateInterval"`
+	Count          int           `json:"count"`
+	Closed         bool          `json:"closed"`
+
+	CurrentFileName   string `json:"currentFileName"`
+	TotalBytesWritten int64  `json:"totalBytesWritten"`
+	TotalLinesWritten int64  `json:"totalLinesWritten"`
+
+	LastRotationTime time.Time `json:"lastRotationTime"`
+	// Estimated time of the next scheduled rotation, nil when no scheduled rotation is set
+	// Note: Interval rotations are skipped when the current file is empty
+	NextRotationTime *time.Time `json:"nextRotationTime"`
+}
+
+// debugState will return a snapshot of the logger's state
+func (l *Logger) debugState() (s DebugState) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	s.Dir = l.dir
+	s.Name = l.name
+	s.NumLines = l.numLines
+	s.RotateInterval = l.rotateInterval
+	s.Count = l.count
+	s.Closed = l.isClosed()
+	s.CurrentFileName = l.filename
+	s.TotalBytesWritten = l.stats.TotalBytesWritten
+	s.TotalLinesWritten = l.stats.TotalLinesWritten
+	s.LastRotationTime = l.stats.LastRotationTime
+	s.NextRotationTime = l.nextRotationTime(time.Now())
+	return
+}
+
+// nextRotationTime will return the estimated time of the next scheduled rotation (if any)
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) nextRotationTime(now time.Time) (next *time.Time) {
+	if l.rotateInterval > 0 {
+		t := l.lastRotationCheck.Add(l.rotateInterval)
+		next = &t
+	}
+
+	if l.midnightTimer != nil {
+		t := now.Add(untilMidnight(now))
+		if next == nil || t.Before(*next) {
+			next = &t
+		}
+	}
+
+	if l.hourlyTimer != nil {
+		t := now.Add(untilNextHour(now))
+		if next == nil || t.Before(*next) {
+			next = &t
+		}
+	}
+
+	return
+}
+
+// NewDebugHandler will return an http.Handler which responds with a JSON snapshot of the logger's state
+func NewDebugHandler(l *Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(l.debugState()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
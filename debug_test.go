@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewDebugHandler(t *testing.T) {
+	var (
+		l *Logger
+
+		state map[string]interface{}
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetRotateInterval(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	NewDebugHandler(l).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/logger", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("invalid status code, expected %d and received %d", http.StatusOK, rec.Code)
+	}
+
+	if err = json.Unmarshal(rec.Body.Bytes(), &state); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := []string{
+		"dir", "name", "numLines", "rotateInterval", "count", "closed", "currentFileName", "totalBytesWritten",
+		"totalLinesWritten", "lastRotationTime", "nextRotationTime",
+	}
+
+	for _, key := range keys {
+		if _, ok := state[key]; !ok {
+			t.Fatalf("expected key \"%s\" to be present", key)
+		}
+	}
+
+	if state["currentFileName"] != l.f.Name() {
+		t.Fatalf("invalid current file name, expected \"%s\" and received \"%v\"", l.f.Name(), state["currentFileName"])
+	}
+
+	if state["count"] != float64(1) {
+		t.Fatalf("invalid count, expected %d and received %v", 1, state["count"])
+	}
+
+	if state["nextRotationTime"] == nil {
+		t.Fatal("expected next rotation time to be set")
+	}
+}
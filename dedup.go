@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+// dedupState represents the previous entry and how many times it has been repeated
+type dedupState struct {
+	// Level of the previous entry
+	level Level
+	// Message of the previous entry, nil when there is no previous entry
+	msg []byte
+	// Time the previous entry was last seen
+	lastSeen time.Time
+	// Number of times the previous entry has been repeated without being written
+	repeats int
+
+	// Timer which writes the summary once the window expires
+	timer *time.Timer
+}
+
+// dedup will return whether or not the entry should be skipped as a repeat of the previous entry
+// Note: Error and Fatal entries are never deduplicated
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) dedup(e entry) (skip bool, err error) {
+	s := &l.dedupState
+	now := time.Now()
+	if e.level < LevelError && s.msg != nil && e.level == s.level && bytes.Equal(e.msg, s.msg) &&
+		now.Sub(s.lastSeen) <= l.dedupWindow {
+		// Entry repeats the previous entry within our window, increment repeats rather than writing
+		s.repeats++
+		s.lastSeen = now
+
+		if s.timer == nil {
+			// Write the summary once the window has expired without further repeats
+			s.timer = time.AfterFunc(l.dedupWindow, l.expireDedup)
+		} else {
+			s.timer.Reset(l.dedupWindow)
+		}
+
+		return true, nil
+	}
+
+	// Entry differs from the previous entry, write summary for the previous entry (if needed)
+	if err = l.writeDedupSummary(); err != nil {
+		return
+	}
+
+	if e.level >= LevelError {
+		// Error and Fatal entries are always written and do not become the previous entry
+		return
+	}
+
+	// Set entry as the previous entry
+	s.level = e.level
+	s.msg = append([]byte(nil), e.msg...)
+	s.lastSeen = now
+	return
+}
+
+// writeDedupSummary will write a summary entry for the repeats of the previous entry (if any) and clear the state
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) writeDedupSummary() (err error) {
+	s := &l.dedupState
+	repeats := s.repeats
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+
+	s.msg = nil
+	s.repeats = 0
+
+	if repeats == 0 {
+		// Previous entry was not repeated, return
+		return
+	}
+
+	summary := fmt.Sprintf("[previous message repeated %d times]", repeats)
+	return l.commitEntry(newEntry(levelNone, []byte(summary)))
+}
+
+// expireDedup is called once the deduplication window has expired
+func (l *Logger) expireDedup() {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.unlock()
+
+	if l.isClosed() {
+		// Instance of logger has been closed, the summary is written on close, return
+		return
+	}
+
+	// Window has expired, write summary and clear the previous entry
+	if err := l.writeDedupSummary(); err != nil {
+		l.addPending(newErrorCall(l.errorHandler(), err))
+	}
+}
+
+// stopDedup will write the pending summary (if any) and stop the deduplication timer
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) stopDedup() {
+	if err := l.writeDedupSummary(); err != nil {
+		l.addPending(newErrorCall(l.errorHandler(), err))
+	}
+}
+
+// SetDedup will set the window within which repeated entries are deduplicated, a window of zero disables deduplication
+// Note: When an entry is identical to the previous entry (same level and message) and arrives within the window of the
+// previous occurrence, it is counted rather than written. Once a different entry arrives or the window expires, a
+// summary entry is written, E.g. [previous message repeated 3 times]. Error and Fatal entries are always written
+func (l *Logger) SetDedup(window time.Duration) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	// Write the pending summary (if any) before changing the window
+	l.stopDedup()
+	l.dedupWindow = window
+	return
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSetDedup(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetDedup(time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := []string{"retrying", "retrying", "retrying", "connected", "connected"}
+	for _, msg := range msgs {
+		if err = l.LogString(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Error entries are never deduplicated
+	for i := 0; i < 2; i++ {
+		if err = l.Error([]byte("failed")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"retrying",
+		"[previous message repeated 2 times]",
+		"connected",
+		"[previous message repeated 1 times]",
+		"ERROR@failed",
+		"ERROR@failed",
+	}
+
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
+
+func TestSetDedup_expire(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetDedup(time.Millisecond * 50); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err = l.LogString("retrying"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := l.Stats().TotalLinesWritten; n != 1 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 1, n)
+	}
+
+	// Wait for the window to expire and the summary to be written
+	deadline := time.Now().Add(time.Second)
+	for l.Stats().TotalLinesWritten < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	if n := l.Stats().TotalLinesWritten; n != 2 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 2, n)
+	}
+}
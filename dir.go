@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hatchify/errors"
+)
+
+// defaultDirPermissions is the default mode used when creating the log directory
+const defaultDirPermissions os.FileMode = 0755
+
+// createDir will create the log directory (and any missing parents) if it does not exist
+func (l *Logger) createDir() (err error) {
+	if _, err = os.Stat(l.dir); err == nil {
+		// Directory already exists, return
+		return
+	}
+
+	if err = os.MkdirAll(l.dir, l.dirPerm); err != nil {
+		return fmt.Errorf("error creating log directory \"%s\": %w", l.dir, err)
+	}
+
+	l.createdDir = true
+	return
+}
+
+// SetDirPermissions will set the mode of the log directory when it was created by the logger (defaults to 0755)
+// Note: The directory is created by New before options are applied, so the mode is updated in place. Directories
+// which existed prior to the logger being created are left untouched, as are any created parent directories
+func (l *Logger) SetDirPermissions(mode os.FileMode) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.dirPerm = mode
+	if !l.createdDir {
+		// Directory was not created by the logger, return
+		return
+	}
+
+	return os.Chmod(l.dir, mode)
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestNew_create_dir(t *testing.T) {
+	var (
+		l    *Logger
+		info os.FileInfo
+		err  error
+	)
+
+	defer os.RemoveAll(testDir)
+
+	dir := path.Join(testDir, "a", "b", "c")
+	if l, err = NewWithOptions(dir, testName, WithDirPermissions(0700)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("hello world"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if info, err = os.Stat(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if !info.IsDir() {
+		t.Fatalf("invalid file, expected \"%s\" to be a directory", dir)
+	}
+
+	if mode := info.Mode().Perm(); mode != 0700 {
+		t.Fatalf("invalid mode, expected %v and received %v", os.FileMode(0700), mode)
+	}
+
+	if path.Dir(filename) != dir {
+		t.Fatalf("invalid directory, expected \"%s\" and received \"%s\"", dir, path.Dir(filename))
+	}
+
+	if info, err = os.Stat(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Size() == 0 {
+		t.Fatalf("invalid file size, expected a non-zero size for \"%s\"", filename)
+	}
+}
+
+func TestSetDirPermissions_existing(t *testing.T) {
+	var (
+		l    *Logger
+		info os.FileInfo
+		err  error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetDirPermissions(0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if info, err = os.Stat(testDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// Existing directories are left untouched
+	if mode := info.Mode().Perm(); mode != 0744 {
+		t.Fatalf("invalid mode, expected %v and received %v", os.FileMode(0744), mode)
+	}
+}
+
+func TestNew_create_dir_error(t *testing.T) {
+	var err error
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	// Create a file where a parent directory is expected
+	parent := path.Join(testDir, "file")
+	if err = ioutil.WriteFile(parent, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = New(path.Join(parent, "logs"), testName)
+
+	var perr *os.PathError
+	if !errors.As(err, &perr) {
+		t.Fatalf("invalid error, expected a wrapped *os.PathError and received %v", err)
+	}
+}
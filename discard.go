@@ -0,0 +1,9 @@
+package logger
+
+// Discard will return a Logger which drops all messages without performing any I/O
+// Note: Messages are still validated, and all configuration methods will silently succeed
+func Discard() *Logger {
+	l := newLogger("", "")
+	l.discard = true
+	return l
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDiscard(t *testing.T) {
+	var (
+		infos []os.FileInfo
+		err   error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	// Change into the test directory to ensure no files are created relative to the working directory
+	var wd string
+	if wd, err = os.Getwd(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.Chdir(testDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	l := Discard()
+	l.SetNumLines(1)
+	l.SetMaxBytes(1)
+	l.SetCompressOnRotate(true)
+
+	if err = l.SetRotateInterval(time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetBufferSize(1024); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetLatestSymlink(true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("bad\nmessage"); err != ErrMessageContainsNewline {
+		t.Fatalf("invalid error, expected %v and received %v", ErrMessageContainsNewline, err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err = l.LogString("#1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(time.Millisecond * 10)
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if infos, err = ioutil.ReadDir("."); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(infos) != 0 {
+		t.Fatalf("expected no files to be created, found %d", len(infos))
+	}
+}
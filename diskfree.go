@@ -0,0 +1,40 @@
+package logger
+
+import "fmt"
+
+// diskFree returns the free disk space of a directory, this is replaced within tests
+var diskFree = getDiskFree
+
+// checkDiskFree will ensure the log directory has at least the minimum free disk space (if set)
+func (l *Logger) checkDiskFree() (err error) {
+	min := l.minFreeDisk.Load()
+	if min <= 0 || l.discard {
+		// Minimum free disk space is not set (or the logger has no underlying file), return
+		return
+	}
+
+	var free uint64
+	if free, err = diskFree(l.dir); err != nil {
+		return fmt.Errorf("error checking free disk space: %v", err)
+	}
+
+	if free < uint64(min) {
+		return ErrDiskFull
+	}
+
+	return
+}
+
+// SetMinFreeDisk will set the minimum number of free bytes required on the log directory's filesystem, messages
+// logged while free space is below the minimum return ErrDiskFull
+// Note: Free space is checked before acquiring the lock (best-effort) and again before writing, which requires a
+// system call per check. A minimum of zero disables the check (default). On platforms without statfs (E.g. Windows),
+// a warning is passed to the error handler and the check is not enabled
+func (l *Logger) SetMinFreeDisk(bytes int64) {
+	if !diskFreeSupported && bytes > 0 {
+		l.handleError(ErrMinFreeDiskUnsupported)
+		return
+	}
+
+	l.minFreeDisk.Store(bytes)
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin && !freebsd && !dragonfly
+// +build !linux,!darwin,!freebsd,!dragonfly
+
+package logger
+
+// diskFreeSupported is whether or not free disk space can be determined on this platform
+const diskFreeSupported = false
+
+// getDiskFree is a stub for platforms which do not support statfs
+func getDiskFree(dir string) (free uint64, err error) {
+	return
+}
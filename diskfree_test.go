@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSetMinFreeDisk(t *testing.T) {
+	var (
+		l   *Logger
+		err error
+	)
+
+	if !diskFreeSupported {
+		t.Skip("free disk space checks are not supported on this platform")
+	}
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	// Mock free disk space
+	var free uint64
+	diskFree = func(dir string) (uint64, error) {
+		return free, nil
+	}
+	defer func() { diskFree = getDiskFree }()
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.SetMinFreeDisk(1024)
+
+	if err = l.LogString("#1"); err != ErrDiskFull {
+		t.Fatalf("invalid error, expected %v and received %v", ErrDiskFull, err)
+	}
+
+	if err = l.LogMany([][]byte{[]byte("#1")}); err != ErrDiskFull {
+		t.Fatalf("invalid error, expected %v and received %v", ErrDiskFull, err)
+	}
+
+	free = 1024
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	free = 0
+	l.SetMinFreeDisk(0)
+	if err = l.LogString("#2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := l.Stats().TotalLinesWritten; count != 2 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 2, count)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build linux || darwin || freebsd || dragonfly
+// +build linux darwin freebsd dragonfly
+
+package logger
+
+import "syscall"
+
+// diskFreeSupported is whether or not free disk space can be determined on this platform
+const diskFreeSupported = true
+
+// getDiskFree will return the number of bytes available to unprivileged users on the filesystem containing dir
+func getDiskFree(dir string) (free uint64, err error) {
+	var st syscall.Statfs_t
+	if err = syscall.Statfs(dir, &st); err != nil {
+		return
+	}
+
+	free = uint64(st.Bavail) * uint64(st.Bsize)
+	return
+}
@@ -0,0 +1,144 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"os"
+
+	"github.com/hatchify/errors"
+)
+
+// encryptionKeySize is the size of an AES-256 key
+const encryptionKeySize = 32
+
+// newAEAD will return an AES-256-GCM cipher for the provided key
+func newAEAD(key []byte) (aead cipher.AEAD, err error) {
+	if len(key) != encryptionKeySize {
+		return nil, ErrInvalidEncryptionKey
+	}
+
+	var block cipher.Block
+	if block, err = aes.NewCipher(key); err != nil {
+		return
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptEntry will return the Base64 encoded nonce, ciphertext and auth tag of the entry line appended to dst
+func encryptEntry(dst []byte, aead cipher.AEAD, line []byte) (out []byte, err error) {
+	nonceSize := aead.NonceSize()
+	sealed := make([]byte, nonceSize, nonceSize+len(line)+aead.Overhead())
+	if _, err = rand.Read(sealed); err != nil {
+		return
+	}
+
+	// Nonce is prepended to the ciphertext, auth tag is appended by Seal
+	sealed = aead.Seal(sealed, sealed, line, nil)
+
+	n := len(dst)
+	out = append(dst, make([]byte, base64.StdEncoding.EncodedLen(len(sealed)))...)
+	base64.StdEncoding.Encode(out[n:], sealed)
+	return
+}
+
+// encryptLines will encrypt each line of the provided buffer (E.g. a CSV header row and its entry)
+// Note: The returned buffer does not share memory with the provided buffer
+func encryptLines(aead cipher.AEAD, buf []byte) (out []byte, err error) {
+	for i, line := range bytes.Split(buf, newline) {
+		if i > 0 {
+			out = append(out, '\n')
+		}
+
+		if out, err = encryptEntry(out, aead, line); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// encryptLengthPrefixed will encrypt a length-prefixed entry (E.g. MessagePack), updating the length prefix to the size
+// of the encrypted entry
+// Note: The returned buffer does not share memory with the provided buffer
+func encryptLengthPrefixed(aead cipher.AEAD, buf []byte) (out []byte, err error) {
+	out = make([]byte, lengthPrefixSize)
+	if out, err = encryptEntry(out, aead, buf[lengthPrefixSize:]); err != nil {
+		return
+	}
+
+	binary.BigEndian.PutUint32(out, uint32(len(out)-lengthPrefixSize))
+	return
+}
+
+// decryptEntry will return the decrypted entry line of a Base64 encoded encrypted line
+func decryptEntry(aead cipher.AEAD, line []byte) (out []byte, err error) {
+	sealed := make([]byte, base64.StdEncoding.DecodedLen(len(line)))
+	var n int
+	if n, err = base64.StdEncoding.Decode(sealed, line); err != nil {
+		return nil, ErrDecryptionFailed
+	}
+
+	sealed = sealed[:n]
+	nonceSize := aead.NonceSize()
+	if len(sealed) < nonceSize+aead.Overhead() {
+		return nil, ErrDecryptionFailed
+	}
+
+	if out, err = aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil); err != nil {
+		return nil, ErrDecryptionFailed
+	}
+
+	return
+}
+
+// OpenEncrypted will open a log file written with an encryption key for reading, decrypting each entry with the
+// provided key
+// Note: Next returns ErrDecryptionFailed for entries which cannot be decrypted (E.g. a wrong key), subsequent calls will
+// continue with the following entry. ForEach returns ErrDecryptionFailed and stops iterating
+func OpenEncrypted(filename string, key []byte) (rp *Reader, err error) {
+	var aead cipher.AEAD
+	if aead, err = newAEAD(key); err != nil {
+		return
+	}
+
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+
+	rp = newReader(f)
+	rp.aead = aead
+	return
+}
+
+// SetEncryptionKey will set the AES-256 key used to encrypt each entry, an empty key disables encryption
+// Note: The key must be 32 bytes. Each line is encrypted with AES-256-GCM using a random 12-byte nonce, and
+// written as the Base64 encoded nonce, ciphertext and auth tag. MessagePack entries are encrypted the same way, within
+// their length prefix. Use OpenEncrypted to read encrypted files
+func (l *Logger) SetEncryptionKey(key []byte) (err error) {
+	var aead cipher.AEAD
+	if len(key) > 0 {
+		if aead, err = newAEAD(key); err != nil {
+			return
+		}
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.aead = aead
+	return
+}
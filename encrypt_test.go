@@ -0,0 +1,183 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSetEncryptionKey(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		bs  []byte
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	key := bytes.Repeat([]byte{'k'}, 32)
+	if l, err = NewWithOptions(testDir, testName, WithEncryptionKey(key)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetEncryptionKey([]byte("short")); err != ErrInvalidEncryptionKey {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidEncryptionKey, err)
+	}
+
+	filename := l.f.Name()
+	for i := 0; i < 3; i++ {
+		if err = l.Info([]byte(fmt.Sprintf("secret #%d", i+1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if bs, err = ioutil.ReadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(bs, []byte("secret")) {
+		t.Fatalf("invalid file, expected entries to be encrypted and received %q", bs)
+	}
+
+	if lines := bytes.Count(bs, newline); lines != 3 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 3, lines)
+	}
+
+	// Without the key, lines are not readable as entries
+	if r, err = Open(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = r.Next(); err == nil {
+		t.Fatal("invalid error, expected an error reading an encrypted entry without the key")
+	}
+
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = OpenEncrypted(filename, key); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		var e Entry
+		if e, err = r.Next(); err != nil {
+			t.Fatal(err)
+		}
+
+		if expected := fmt.Sprintf("secret #%d", i+1); e.Message != expected {
+			t.Fatalf("invalid message, expected %s and received %s", expected, e.Message)
+		}
+
+		if expected := LevelInfo.String(); e.Level != expected {
+			t.Fatalf("invalid level, expected %s and received %s", expected, e.Level)
+		}
+	}
+
+	if _, err = r.Next(); err != io.EOF {
+		t.Fatalf("invalid error, expected %v and received %v", io.EOF, err)
+	}
+
+	var count int
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		if !bytes.Contains(log, []byte("secret")) {
+			t.Fatalf("invalid log, expected a decrypted entry and received %q", log)
+		}
+
+		count++
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 3 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 3, count)
+	}
+
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// With the wrong key, entries cannot be decrypted
+	if r, err = OpenEncrypted(filename, bytes.Repeat([]byte{'x'}, 32)); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if _, err = r.Next(); err != ErrDecryptionFailed {
+		t.Fatalf("invalid error, expected %v and received %v", ErrDecryptionFailed, err)
+	}
+}
+
+func TestSetEncryptionKey_messagePack(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+		e Entry
+
+		bs  []byte
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	key := bytes.Repeat([]byte{'k'}, 32)
+	if l, err = NewWithOptions(testDir, testName, WithMessagePack(true), WithEncryptionKey(key)); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	for i := 0; i < 3; i++ {
+		if err = l.Info([]byte(fmt.Sprintf("secret #%d", i+1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if bs, err = ioutil.ReadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(bs, []byte("secret")) {
+		t.Fatalf("invalid file, expected entries to be encrypted and received %q", bs)
+	}
+
+	if r, err = OpenEncrypted(filename, key); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for i := 0; i < 3; i++ {
+		if e, err = r.Next(); err != nil {
+			t.Fatal(err)
+		}
+
+		if expected := fmt.Sprintf("secret #%d", i+1); e.Message != expected {
+			t.Fatalf("invalid message, expected %s and received %s", expected, e.Message)
+		}
+	}
+
+	if _, err = r.Next(); err != io.EOF {
+		t.Fatalf("invalid error, expected %v and received %v", io.EOF, err)
+	}
+}
@@ -0,0 +1,222 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// newEntry will return a new entry
+func newEntry(level Level, msg []byte) (e entry) {
+	e.level = level
+	e.msg = msg
+	return
+}
+
+// entry represents a log entry prior to being formatted
+type entry struct {
+	// Level of the entry, levelNone for entries without a level
+	level Level
+	// Hostname, set when hostname inclusion is enabled
+	hostname string
+	// Process ID, set when PID inclusion is enabled
+	pid int
+	// Caller file and line, set when caller depth is set
+	caller string
+	// Prefix prepended to the message, set when a prefix is set
+	prefix []byte
+	// Log message
+	msg []byte
+	// Fields, set when fields have been added
+	fields []field
+	// Fields extracted from the context, set for entries logged with LogContext when context extractors are registered
+	ctxFields []field
+	// Pre-encoded JSON members (each preceded by a comma), set for structured entries (E.g. from a slog handler)
+	// Note: Entries with attributes are always written in the JSON format
+	attrs []byte
+
+	// Condensed stack trace, set when stack capture is enabled for the entry level
+	stack []byte
+	// Delimiter used to separate the message and stack
+	stackDelim []byte
+}
+
+// appendTextEntry will append the full entry (prefix, message) to the provided buffer
+// Note: Entries are formatted as timestamp@[level@][hostname@][pid@][caller@][prefix]message[<delimiter>stack], where '@' is
+// the separator. Optional fields are omitted entirely (including their separator) when they are not enabled
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) appendTextEntry(buf []byte, e entry) []byte {
+	// Append timestamp
+	buf = l.appendTimestamp(buf)
+	// Append separator, which separates timestamp and the message
+	buf = append(buf, l.sep)
+
+	if e.level != levelNone {
+		// Append level
+		buf = append(buf, e.level.bytes()...)
+		// Append separator, which separates level and the message
+		buf = append(buf, l.sep)
+	}
+
+	if e.hostname != "" {
+		// Append hostname
+		buf = append(buf, e.hostname...)
+		// Append separator, which separates hostname and the message
+		buf = append(buf, l.sep)
+	}
+
+	if e.pid != 0 {
+		// Append process ID
+		buf = strconv.AppendInt(buf, int64(e.pid), 10)
+		// Append separator, which separates process ID and the message
+		buf = append(buf, l.sep)
+	}
+
+	if e.caller != "" {
+		// Append caller
+		buf = append(buf, e.caller...)
+		// Append separator, which separates caller and the message
+		buf = append(buf, l.sep)
+	}
+
+	// Append prefix (if set)
+	buf = append(buf, e.prefix...)
+	// Append message
+	buf = append(buf, e.msg...)
+
+	if len(e.stack) > 0 {
+		// Append delimiter, which separates the message and the stack
+		buf = append(buf, e.stackDelim...)
+		// Append stack
+		buf = append(buf, e.stack...)
+	}
+
+	return buf
+}
+
+// Entry represents a parsed log entry
+// Note: Hostname, PID, Caller, Stack and Extra are only populated for entries written in the JSON or logfmt formats
+type Entry struct {
+	// Unix timestamp (in nanoseconds)
+	Timestamp int64
+	// Level name, empty for entries without a level
+	Level string
+	// Log message
+	Message string
+
+	// Hostname, empty when hostname inclusion was not enabled
+	Hostname string
+	// Process ID, zero when PID inclusion was not enabled
+	PID int
+	// Caller file and line, empty when caller depth was not set
+	Caller string
+	// Condensed stack trace, empty when stack capture was not enabled for the level
+	Stack string
+	// Additional fields (E.g. fields added with AddField), nil when there are no additional fields
+	Extra map[string]string
+}
+
+// setField will set the entry field matching the provided key, unrecognized keys are stored within Extra
+func (e *Entry) setField(key, value string) (err error) {
+	switch key {
+	case "ts":
+		e.Timestamp, err = strconv.ParseInt(value, 10, 64)
+	case "level":
+		e.Level = value
+	case "msg":
+		e.Message = value
+	case "hostname":
+		e.Hostname = value
+	case "pid":
+		e.PID, err = strconv.Atoi(value)
+	case "caller":
+		e.Caller = value
+	case "stack":
+		e.Stack = value
+
+	default:
+		if e.Extra == nil {
+			e.Extra = make(map[string]string)
+		}
+
+		e.Extra[key] = value
+	}
+
+	return
+}
+
+// parseEntry will parse a log line into an Entry
+// Note: The format is detected per line, lines beginning with '{' are parsed as JSON and lines beginning with "ts="
+// are parsed as logfmt. Length-prefixed entries beginning with a map header are parsed as MessagePack. All other lines
+// are parsed as text
+func parseEntry(line []byte) (e Entry, err error) {
+	switch {
+	case len(line) > 0 && isMessagePackMap(line[0]):
+		if e, err = parseMessagePackEntry(line); err != nil {
+			err = newParseError(line, err)
+		}
+
+		return
+	case len(line) > 0 && line[0] == '{':
+		if e, err = parseJSONEntry(line); err != nil {
+			err = newParseError(line, err)
+		}
+
+		return
+	case bytes.HasPrefix(line, logfmtTimestampKey):
+		if e, err = parseLogfmtEntry(line); err != nil {
+			err = newParseError(line, err)
+		}
+
+		return
+	}
+
+	ts, log, err := parseLine(line)
+	if err != nil {
+		err = newParseError(line, err)
+		return
+	}
+
+	e.Timestamp = ts.UnixNano()
+	e.Level, log = parseLevel(log)
+	e.Message = string(log)
+	return
+}
+
+// parseLevel will parse the level from the beginning of log bytes (if it exists)
+func parseLevel(log []byte) (level string, msg []byte) {
+	for i, bs := range levelBytes {
+		n := len(bs)
+		if len(log) <= n || log[n] != defaultSeparator || !bytes.Equal(log[:n], bs) {
+			continue
+		}
+
+		return Level(i).name(), log[n+1:]
+	}
+
+	return "", log
+}
+
+// newParseError will return a new parse error
+func newParseError(line []byte, err error) *ParseError {
+	var e ParseError
+	e.line = string(line)
+	e.err = err
+	return &e
+}
+
+// ParseError is returned when a log line cannot be parsed
+type ParseError struct {
+	line string
+	err  error
+}
+
+// Error will return the error message
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("error parsing line \"%s\": %v", e.line, e.err)
+}
+
+// RawLine will return the raw line which could not be parsed
+func (e *ParseError) RawLine() string {
+	return e.line
+}
@@ -0,0 +1,149 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSetSeparator(t *testing.T) {
+	var (
+		l *Logger
+		f *os.File
+
+		lines []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetSeparator('\n'); err != ErrInvalidSeparator {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidSeparator, err)
+	}
+
+	if err = l.SetSeparator('|'); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Warn([]byte("user@example.com | signed in")); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if f, err = os.Open(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+
+	if len(lines) != 1 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 1, len(lines))
+	}
+
+	parts := bytes.SplitN([]byte(lines[0]), []byte("|"), 3)
+	if len(parts) != 3 {
+		t.Fatalf("invalid line: \"%s\"", lines[0])
+	}
+
+	if level := string(parts[1]); level != "WARN " {
+		t.Fatalf("invalid level, expected \"%s\" and received \"%s\"", "WARN ", level)
+	}
+
+	if msg := string(parts[2]); msg != "user@example.com | signed in" {
+		t.Fatalf("invalid message, expected \"%s\" and received \"%s\"", "user@example.com | signed in", msg)
+	}
+}
+
+func TestSetPrefix(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetPrefix([]byte("bad\nprefix")); err != ErrInvalidPrefix {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidPrefix, err)
+	}
+
+	if err = l.SetPrefix([]byte("[db] ")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetPrefix([]byte("[http] ")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Info([]byte("#2")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetPrefix(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#3"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"[db] #1", "INFO @[http] #2", "#3"}
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
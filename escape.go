@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+)
+
+var (
+	// escapedBackslash is the two-character replacement for a backslash
+	escapedBackslash = []byte(`\\`)
+	// escapedNewline is the two-character replacement for a newline
+	escapedNewline = []byte(`\n`)
+	// escapedCarriageReturn is the two-character replacement for a carriage return
+	escapedCarriageReturn = []byte(`\r`)
+)
+
+// validateMessage will ensure the message does not contain a newline, unless newline escaping or Base64 encoding is
+// enabled
+// Note: This is called before the lock is acquired
+func (l *Logger) validateMessage(msg []byte) (err error) {
+	if bytes.Index(msg, newline) == -1 || l.escapeNewlines.Get() || l.base64Messages.Get() {
+		return
+	}
+
+	// Log message contains a newline, return
+	return ErrMessageContainsNewline
+}
+
+// escapeNewlines will replace backslashes, newlines and carriage returns within the message with their two-character
+// literals (\\, \n and \r)
+func escapeNewlines(msg []byte) []byte {
+	if bytes.IndexAny(msg, "\\\r\n") == -1 {
+		// Message does not need to be escaped, return
+		return msg
+	}
+
+	buf := make([]byte, 0, len(msg)+8)
+	for _, c := range msg {
+		switch c {
+		case '\\':
+			buf = append(buf, escapedBackslash...)
+		case '\n':
+			buf = append(buf, escapedNewline...)
+		case '\r':
+			buf = append(buf, escapedCarriageReturn...)
+		default:
+			buf = append(buf, c)
+		}
+	}
+
+	return buf
+}
+
+// unescapeNewlines will replace the two-character literals of backslashes, newlines and carriage returns with their
+// characters
+// Note: Backslashes which do not precede an escaped character are returned as-is
+func unescapeNewlines(msg string) string {
+	if strings.IndexByte(msg, '\\') == -1 {
+		// Message does not contain any escaped characters, return
+		return msg
+	}
+
+	buf := make([]byte, 0, len(msg))
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c != '\\' || i+1 == len(msg) {
+			buf = append(buf, c)
+			continue
+		}
+
+		switch msg[i+1] {
+		case '\\':
+			buf = append(buf, '\\')
+		case 'n':
+			buf = append(buf, '\n')
+		case 'r':
+			buf = append(buf, '\r')
+		default:
+			// Backslash does not precede an escaped character, write as-is
+			buf = append(buf, c)
+			continue
+		}
+
+		i++
+	}
+
+	return string(buf)
+}
+
+// SetEscapeNewlines will set whether or not newlines within messages are escaped rather than rejected
+// Note: When enabled, newlines and carriage returns are written as the two-character literals \n and \r, allowing
+// multi-line messages (E.g. stack traces) to be stored as a single line. Backslashes are written as \\, so messages
+// containing literal backslash sequences (E.g. C:\new) are restored exactly using Reader.Unescape
+func (l *Logger) SetEscapeNewlines(escape bool) {
+	l.escapeNewlines.Set(escape)
+}
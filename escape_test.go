@@ -0,0 +1,144 @@
+package logger
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetEscapeNewlines(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := "panic: oh no\r\ngoroutine 1 [running]:\nmain.main()"
+	if err = l.LogString(msg); err != ErrMessageContainsNewline {
+		t.Fatalf("invalid error, expected %v and received %v", ErrMessageContainsNewline, err)
+	}
+
+	l.SetEscapeNewlines(true)
+
+	if err = l.LogString(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logs) != 1 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 1, len(logs))
+	}
+
+	escaped := `panic: oh no\r\ngoroutine 1 [running]:\nmain.main()`
+	if logs[0] != escaped {
+		t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", escaped, logs[0])
+	}
+
+	if unescaped := r.Unescape(logs[0]); unescaped != msg {
+		t.Fatalf("invalid unescaped log, expected %q and received %q", msg, unescaped)
+	}
+}
+
+func TestEscapeNewlines_Backslashes(t *testing.T) {
+	for _, msg := range []string{
+		`C:\new`,
+		`C:\\new`,
+		`trailing\`,
+		"literal \\n and real\nnewline\r",
+		`\\\r\\n`,
+	} {
+		escaped := string(escapeNewlines([]byte(msg)))
+		if strings.ContainsAny(escaped, "\r\n") {
+			t.Fatalf("invalid escaped message, expected no newlines and received %q", escaped)
+		}
+
+		if unescaped := unescapeNewlines(escaped); unescaped != msg {
+			t.Fatalf("invalid unescaped message, expected %q and received %q", msg, unescaped)
+		}
+	}
+}
+
+func TestSetEscapeNewlines_hook(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = NewWithOptions(testDir, testName, WithEscapeNewlines(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.AddHook(func(msg []byte) ([]byte, error) {
+		return append([]byte(`C:\dir `), msg...), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("a\nb"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logs) != 1 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 1, len(logs))
+	}
+
+	// Message is escaped exactly once, after the hook has been applied
+	if expected := "C:\\dir a\nb"; r.Unescape(logs[0]) != expected {
+		t.Fatalf("invalid unescaped log, expected %q and received %q", expected, r.Unescape(logs[0]))
+	}
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// NewFromExistingFile will return a new instance of Logger which writes to an already open file (E.g. an inherited
+// file descriptor). Rotated files are created within the directory of the provided file using the provided name
+// Note: Entries are appended to the end of the file. The line count starts from zero when the logger takes over (lines
+// already within the file are not counted towards the line limit), while the file size includes the existing contents.
+// The provided file is never removed, renamed (see SetHashInFilename) or processed (E.g. compressed) by the logger
+// Pipes and sockets are supported, they are written to as-is without seeking or syncing
+func NewFromExistingFile(f *os.File, name string) (lp *Logger, err error) {
+	if name == "" {
+		return nil, ErrInvalidName
+	}
+
+	var info os.FileInfo
+	if info, err = f.Stat(); err != nil {
+		return
+	}
+
+	// Ensure entries are appended, the file may not have been opened with O_APPEND
+	// Note: Pipes, sockets and character devices cannot seek and are always written to in order
+	if info.Mode().IsRegular() {
+		if _, err = f.Seek(0, io.SeekEnd); err != nil {
+			return
+		}
+	}
+
+	l := newLogger(filepath.Dir(f.Name()), name)
+	l.f = f
+	l.filename = f.Name()
+	l.inherited = true
+	l.unsyncable = !info.Mode().IsRegular()
+	l.openedAt = time.Now()
+	l.w = l.newWriter()
+	l.size = info.Size()
+
+	// Replay any entries left within the write-ahead log by a crashed process
+	if err = l.replayWAL(); err != nil {
+		return
+	}
+
+	// Assign lp as our created logger
+	lp = l
+	return
+}
@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestNewFromExistingFile(t *testing.T) {
+	var (
+		f *os.File
+		l *Logger
+
+		bs  []byte
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	filename := path.Join(testDir, "existing.log")
+	if f, err = os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err = fmt.Fprintf(f, "manual #%d\n", i+1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Rewind so the logger must seek to the end of the file
+	if _, err = f.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = NewFromExistingFile(f, ""); err != ErrInvalidName {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidName, err)
+	}
+
+	if l, err = NewFromExistingFile(f, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.dir != testDir {
+		t.Fatalf("invalid directory, expected \"%s\" and received \"%s\"", testDir, l.dir)
+	}
+
+	// Line count starts from zero when the logger takes over
+	l.SetNumLines(3)
+
+	for i := 0; i < 3; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if l.f.Name() == filename {
+		t.Fatal("expected logger to rotate after reaching the line limit")
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if bs, err = ioutil.ReadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := bytes.Split(bytes.TrimSuffix(bs, newline), newline)
+	if len(lines) != 8 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 8, len(lines))
+	}
+
+	for i := 0; i < 5; i++ {
+		if expected := fmt.Sprintf("manual #%d", i+1); string(lines[i]) != expected {
+			t.Fatalf("invalid line, expected \"%s\" and received \"%s\"", expected, lines[i])
+		}
+	}
+
+	for i, line := range lines[5:] {
+		if expected := fmt.Sprintf("#%d", i+1); !bytes.HasSuffix(line, []byte(expected)) {
+			t.Fatalf("invalid line, expected suffix \"%s\" and received \"%s\"", expected, line)
+		}
+	}
+}
+
+func TestNewFromExistingFile_CloseWithoutEntries(t *testing.T) {
+	var (
+		f *os.File
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	filename := path.Join(testDir, "existing.log")
+	if f, err = os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if l, err = NewFromExistingFile(f, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetHashInFilename(true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The inherited file belongs to the caller, it must not be removed even though it is empty
+	if _, err = os.Stat(filename); err != nil {
+		t.Fatalf("invalid file, expected \"%s\" to exist: %v", filename, err)
+	}
+}
+
+func TestNewFromExistingFile_pipe(t *testing.T) {
+	var (
+		pr *os.File
+		pw *os.File
+		l  *Logger
+
+		err error
+	)
+
+	if pr, pw, err = os.Pipe(); err != nil {
+		t.Fatal(err)
+	}
+	defer pr.Close()
+
+	// Pipes cannot seek, the logger should write to them as-is
+	if l, err = NewFromExistingFile(pw, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("piped"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	line, err := bufio.NewReader(pr).ReadBytes('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, msg, err := parseLine(bytes.TrimSuffix(line, newline)); err != nil {
+		t.Fatal(err)
+	} else if string(msg) != "piped" {
+		t.Fatalf("invalid message, expected \"%s\" and received \"%s\"", "piped", msg)
+	}
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+)
+
+// expvars are the loggers published with RegisterExpvar, by key
+var expvars = struct {
+	mu   sync.Mutex
+	vars map[string]*expvarLogger
+}{vars: make(map[string]*expvarLogger)}
+
+// expvarLogger holds the logger published under an expvar key, the logger is swapped when the key is re-registered
+type expvarLogger struct {
+	l atomic.Value
+}
+
+// stats will return the stats of the published logger
+func (e *expvarLogger) stats() interface{} {
+	return e.l.Load().(*Logger).Stats()
+}
+
+// RegisterExpvar will publish the stats of the logger as a JSON object under the provided key (E.g. at /debug/vars)
+// Note: Registering an already registered key replaces the previously registered logger. As with expvar.Publish, this
+// will panic if the key has already been published by other means
+func RegisterExpvar(l *Logger, key string) {
+	// Acquire lock
+	expvars.mu.Lock()
+	// Defer the release of our lock
+	defer expvars.mu.Unlock()
+
+	if e, ok := expvars.vars[key]; ok {
+		// Key has already been registered, expvar does not allow re-publishing so swap the logger
+		e.l.Store(l)
+		return
+	}
+
+	var e expvarLogger
+	e.l.Store(l)
+	expvar.Publish(key, expvar.Func(e.stats))
+	expvars.vars[key] = &e
+}
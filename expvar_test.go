@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestRegisterExpvar(t *testing.T) {
+	var (
+		l *Logger
+		r *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if r, err = New(testDir, testName+"_replacement"); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	s := httptest.NewServer(expvar.Handler())
+	defer s.Close()
+
+	RegisterExpvar(l, "logger_testing")
+	for i := 0; i < 3; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stats Stats
+	if stats, err = getExpvarStats(s.URL, "logger_testing"); err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.TotalLinesWritten != 3 {
+		t.Fatalf("invalid total lines written, expected %d and received %d", 3, stats.TotalLinesWritten)
+	}
+
+	// Re-registering the key replaces the previous logger
+	RegisterExpvar(r, "logger_testing")
+	if err = r.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if stats, err = getExpvarStats(s.URL, "logger_testing"); err != nil {
+		t.Fatal(err)
+	}
+
+	if stats.TotalLinesWritten != 1 {
+		t.Fatalf("invalid total lines written, expected %d and received %d", 1, stats.TotalLinesWritten)
+	}
+}
+
+// getExpvarStats will return the logger stats published under the provided key
+func getExpvarStats(url, key string) (stats Stats, err error) {
+	var resp *http.Response
+	if resp, err = http.Get(url + "/debug/vars"); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	var vars map[string]json.RawMessage
+	if err = json.NewDecoder(resp.Body).Decode(&vars); err != nil {
+		return
+	}
+
+	raw, ok := vars[key]
+	if !ok {
+		err = fmt.Errorf("key \"%s\" was not published", key)
+		return
+	}
+
+	err = json.Unmarshal(raw, &stats)
+	return
+}
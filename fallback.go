@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+const (
+	// minFallbackBackoff is the delay before the first attempt to reopen an unavailable file
+	minFallbackBackoff = time.Second
+	// maxFallbackBackoff is the maximum delay between attempts to reopen an unavailable file
+	maxFallbackBackoff = time.Minute
+)
+
+// fallbackState represents the state of the stderr fallback
+type fallbackState struct {
+	// Whether or not the current file is unavailable
+	failed bool
+	// Delay before the next reopen attempt, doubled after each failed attempt
+	backoff time.Duration
+	// Time of the next reopen attempt
+	retryAt time.Time
+}
+
+// writeBufferWithFallback will write the formatted entry buffer, falling back to stderr if the file is unavailable
+// (when enabled). Returns whether or not the entry was written to stderr
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) writeBufferWithFallback() (fellBack bool, err error) {
+	if !l.stderrFallback {
+		// Fallback is not enabled, write entry
+		return false, l.writeBuffer()
+	}
+
+	if l.fallback.failed && !l.reopenFile() {
+		// File is still unavailable, write entry to stderr
+		l.writeStderr(l.buf)
+		// Mirror entry to the tee writer (if set)
+		l.writeTee(l.buf)
+		return true, nil
+	}
+
+	if err = l.writeBuffer(); err == nil || err == ErrWriteTimeout {
+		// Entry was written (or is still being written by a stalled write), return
+		return
+	}
+
+	// File write failed, write entry to stderr until the file can be reopened
+	l.addPending(newErrorCall(l.errorHandler(), fmt.Errorf("error writing to file, falling back to stderr: %v", err)))
+	l.fallback.failed = true
+	l.fallback.backoff = minFallbackBackoff
+	l.fallback.retryAt = time.Now().Add(l.fallback.backoff)
+	l.writeStderr(l.buf)
+	return true, nil
+}
+
+// reopenFile will attempt to reopen the current file once the backoff has elapsed, returning whether or not the file
+// is available
+// Note: Entries which were buffered when the file became unavailable are discarded
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) reopenFile() (ok bool) {
+	now := time.Now()
+	if now.Before(l.fallback.retryAt) {
+		// Backoff has not elapsed, return
+		return false
+	}
+
+	name := l.filename
+	if l.tmpFilename != "" {
+		// File has not been renamed, use the temporary filename
+		name = l.tmpFilename
+	}
+
+	f, err := os.OpenFile(name, loggerFlag, l.filePerm)
+	if err != nil {
+		// File is still unavailable, increase backoff
+		if l.fallback.backoff *= 2; l.fallback.backoff > maxFallbackBackoff {
+			l.fallback.backoff = maxFallbackBackoff
+		}
+
+		l.fallback.retryAt = now.Add(l.fallback.backoff)
+		return false
+	}
+
+	// Close the unavailable file, this is expected to fail
+	l.f.Close()
+	l.f = f
+	l.w = l.newWriter()
+	l.fallback = fallbackState{}
+	return true
+}
+
+// writeStderr will write a raw entry to stderr
+func (l *Logger) writeStderr(bs []byte) {
+	if _, err := os.Stderr.Write(bs); err != nil {
+		l.addPending(newErrorCall(l.errorHandler(), fmt.Errorf("error writing to stderr: %v", err)))
+	}
+}
+
+// SetStderrFallback will set whether or not entries are written to stderr when the file cannot be written to (E.g. an
+// unmounted volume), rather than returning an error
+// Note: The write error is passed to the error handler, and the file is reopened with an exponential backoff (starting
+// at one second, up to one minute). Entries written to stderr are not counted towards the file's line or byte limits
+func (l *Logger) SetStderrFallback(fallback bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.stderrFallback = fallback
+	return
+}
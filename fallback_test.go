@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSetStderrFallback(t *testing.T) {
+	var (
+		l *Logger
+
+		bs   []byte
+		errs []error
+		err  error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	// Capture stderr
+	stderr := os.Stderr
+	defer func() { os.Stderr = stderr }()
+
+	var pr, pw *os.File
+	if pr, pw, err = os.Pipe(); err != nil {
+		t.Fatal(err)
+	}
+	defer pr.Close()
+	os.Stderr = pw
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetErrorHandler(func(err error) {
+		errs = append(errs, err)
+	})
+
+	if err = l.SetStderrFallback(true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace the underlying file with a closed pipe, the small buffer ensures entries are written immediately
+	var br, broken *os.File
+	if br, broken, err = os.Pipe(); err != nil {
+		t.Fatal(err)
+	}
+	br.Close()
+	broken.Close()
+
+	filename := l.f.Name()
+	defer l.f.Close()
+	l.f = broken
+	l.w = bufio.NewWriterSize(broken, 16)
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// File is unavailable until the backoff has elapsed
+	if err = l.LogString("#2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(errs) != 1 {
+		t.Fatalf("invalid number of errors, expected %d and received %d", 1, len(errs))
+	}
+
+	pw.Close()
+	if bs, err = ioutil.ReadAll(pr); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := bytes.Split(bytes.TrimSuffix(bs, newline), newline)
+	if len(lines) != 2 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 2, len(lines))
+	}
+
+	for i, expected := range []string{"#1", "#2"} {
+		if !bytes.HasSuffix(lines[i], []byte(expected)) {
+			t.Fatalf("invalid line, expected suffix \"%s\" and received \"%s\"", expected, lines[i])
+		}
+	}
+
+	// Skip the backoff so the file is reopened by the next write
+	l.mu.Lock()
+	l.fallback.retryAt = time.Time{}
+	l.mu.Unlock()
+
+	if err = l.LogString("#3"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if bs, err = ioutil.ReadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.HasSuffix(bs, []byte("#3\n")) || bytes.Contains(bs, []byte("#1")) {
+		t.Fatalf("invalid file contents, expected only \"#3\" and received \"%s\"", bs)
+	}
+}
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"sort"
+	"strings"
+)
+
+// field represents a key-value pair included within structured entries
+type field struct {
+	key   string
+	value string
+}
+
+// reservedFieldKeys are the keys of the core fields, which cannot be used as field keys
+var reservedFieldKeys = map[string]struct{}{
+	"ts":       {},
+	"level":    {},
+	"msg":      {},
+	"hostname": {},
+	"pid":      {},
+	"caller":   {},
+	"stack":    {},
+}
+
+// isValidFieldKey will return whether or not the provided key can be written without quoting and is not reserved
+func isValidFieldKey(key string) bool {
+	if len(key) == 0 {
+		return false
+	}
+
+	if _, ok := reservedFieldKeys[key]; ok {
+		// Key would duplicate a core field
+		return false
+	}
+
+	return !strings.ContainsAny(key, " =\"\t\r\n")
+}
+
+// updateFieldList will rebuild the sorted field list from the field map
+// Note: A new slice is allocated so entries referencing the previous list are unaffected
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) updateFieldList() {
+	fieldList := make([]field, 0, len(l.fields))
+	for key, value := range l.fields {
+		fieldList = append(fieldList, field{key: key, value: value})
+	}
+
+	sort.Slice(fieldList, func(i, j int) bool {
+		return fieldList[i].key < fieldList[j].key
+	})
+
+	l.fieldList = fieldList
+}
+
+// mergeFields will return the provided fields followed by the additional fields, additional fields replace any fields
+// with a matching key
+// Note: A new slice is allocated so the provided fields are unaffected
+func mergeFields(fields, additional []field) (merged []field) {
+	merged = make([]field, 0, len(fields)+len(additional))
+	for _, f := range fields {
+		if !containsField(additional, f.key) {
+			merged = append(merged, f)
+		}
+	}
+
+	return append(merged, additional...)
+}
+
+// containsField will return whether or not the provided fields contain a field with the provided key
+func containsField(fields []field, key string) bool {
+	for _, f := range fields {
+		if f.key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddField will add a field which is included within every JSON and logfmt entry, replacing any existing value for the key
+// Note: Fields are written in key order following the core fields, E.g. ts=1 level=INFO msg=hello service=api. Text
+// entries do not include fields
+func (l *Logger) AddField(key, value string) (err error) {
+	// Ensure key is valid
+	if !isValidFieldKey(key) {
+		return ErrInvalidFieldKey
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	if l.fields == nil {
+		// Initialize field map
+		l.fields = make(map[string]string)
+	}
+
+	l.fields[key] = value
+	l.updateFieldList()
+	return
+}
+
+// RemoveField will remove a field which was previously added
+func (l *Logger) RemoveField(key string) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	if _, ok := l.fields[key]; !ok {
+		// Field does not exist, return
+		return
+	}
+
+	delete(l.fields, key)
+	l.updateFieldList()
+}
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"bufio"
+	"os"
+	"testing"
+)
+
+func TestAddField(t *testing.T) {
+	var (
+		l *Logger
+		f *os.File
+
+		lines []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.UseJSON(true)
+
+	if err = l.AddField("version", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.AddField("service", "api"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Overwrite the existing version
+	if err = l.AddField("version", "2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Info([]byte("#1")); err != nil {
+		t.Fatal(err)
+	}
+
+	l.RemoveField("service")
+	l.RemoveField("missing")
+
+	if err = l.Info([]byte("#2")); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if f, err = os.Open(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		// Trim the timestamp, which varies between entries
+		lines = append(lines, s.Text()[len(`{"ts":1700000000000000000,`):])
+	}
+
+	expected := []string{
+		`"level":"INFO","msg":"#1","service":"api","version":"2"}`,
+		`"level":"INFO","msg":"#2","version":"2"}`,
+	}
+
+	if len(lines) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(lines))
+	}
+
+	for i, line := range lines {
+		if line != expected[i] {
+			t.Fatalf("invalid line, expected \"%s\" and received \"%s\"", expected[i], line)
+		}
+	}
+}
+
+func TestAddField_reserved(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	for _, key := range []string{"ts", "level", "msg", "hostname", "pid", "caller", "stack"} {
+		if err = l.AddField(key, "value"); err != ErrInvalidFieldKey {
+			t.Fatalf("invalid error for \"%s\", expected %v and received %v", key, ErrInvalidFieldKey, err)
+		}
+	}
+
+	if len(l.fields) != 0 {
+		t.Fatalf("invalid number of fields, expected %d and received %d", 0, len(l.fields))
+	}
+}
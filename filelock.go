@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"os"
+
+	"github.com/hatchify/errors"
+)
+
+// writeLocked will write a raw entry directly to the provided file while holding an exclusive file lock
+// Note: Buffered entries are expected to be flushed by the caller so entries remain in order
+func writeLocked(f *os.File, bs []byte) (n int, err error) {
+	// Acquire exclusive file lock
+	if err = lockFile(f); err != nil {
+		return
+	}
+	// Defer the release of our file lock
+	defer func() {
+		if uerr := unlockFile(f); err == nil {
+			err = uerr
+		}
+	}()
+
+	return f.Write(bs)
+}
+
+// SetFileLock will set whether or not an exclusive file lock is held while writing each entry
+// Note: When enabled, entries bypass the write buffer and are written directly to the file while holding flock(LOCK_EX),
+// allowing multiple processes to safely write to the same file. This requires a system call per entry and
+// significantly reduces throughput. Processes only share a file when their filenames match (E.g. when using
+// SetRotateAtMidnight). On platforms without flock (E.g. Windows), locking is a no-op
+func (l *Logger) SetFileLock(fileLock bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	if fileLock && !l.fileLock {
+		// Flush buffered entries before bypassing the buffer
+		if err = l.flush(); err != nil {
+			return
+		}
+	}
+
+	l.fileLock = fileLock
+	return
+}
@@ -0,0 +1,16 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+package logger
+
+import "os"
+
+// lockFile is a stub for platforms which do not support flock
+func lockFile(f *os.File) error {
+	return nil
+}
+
+// unlockFile is a stub for platforms which do not support flock
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,128 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+const (
+	// fileLockHelperEnv is the environment variable which signals the file lock helper process
+	fileLockHelperEnv = "LOGGER_FILE_LOCK_HELPER"
+	// fileLockLines is the number of lines written by each file lock helper process
+	fileLockLines = 200
+	// fileLockLineSize is the size of the messages written by each file lock helper process
+	fileLockLineSize = 8192
+)
+
+func TestFileLock(t *testing.T) {
+	var (
+		files []logFile
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	cmds := make([]*exec.Cmd, 2)
+	for i := range cmds {
+		cmds[i] = exec.Command(os.Args[0], "-test.run=^TestFileLockHelper$")
+		cmds[i].Env = append(os.Environ(), fileLockHelperEnv+"="+string('a'+rune(i)))
+		if err = cmds[i].Start(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, cmd := range cmds {
+		if err = cmd.Wait(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if files, err = listFiles(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("invalid number of files, expected %d and received %d", 1, len(files))
+	}
+
+	f, err := os.Open(files[0].filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var count int
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, fileLockLineSize*2)
+	for s.Scan() {
+		_, msg, err := parseLine(s.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(msg) != fileLockLineSize || bytes.Count(msg, msg[:1]) != fileLockLineSize {
+			t.Fatalf("invalid line, expected %d matching characters within a line of %d", fileLockLineSize, len(msg))
+		}
+
+		count++
+	}
+
+	if err = s.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != fileLockLines*len(cmds) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", fileLockLines*len(cmds), count)
+	}
+}
+
+func TestFileLockHelper(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	char := os.Getenv(fileLockHelperEnv)
+	if char == "" {
+		t.Skip("only run as a helper process of TestFileLock")
+	}
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	// Use date based filenames so both processes share the same file
+	if err = l.SetRotateAtMidnight(true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetFileLock(true); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := bytes.Repeat([]byte(char), fileLockLineSize)
+	for i := 0; i < fileLockLines; i++ {
+		if err = l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
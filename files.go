@@ -0,0 +1,126 @@
+package logger
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logFile represents a log file within a directory
+type logFile struct {
+	filename string
+	ts       time.Time
+}
+
+// listFiles will return the log files for a given directory and name, sorted by timestamp (oldest first)
+func listFiles(dir, name string) (files []logFile, err error) {
+	var infos []os.FileInfo
+	if infos, err = ioutil.ReadDir(dir); err != nil {
+		return
+	}
+
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+
+		ts, ok := parseFilename(info.Name(), name)
+		if !ok {
+			// Not a log file for this name, continue
+			continue
+		}
+
+		files = append(files, logFile{filename: path.Join(dir, info.Name()), ts: ts})
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].ts.Before(files[j].ts)
+	})
+
+	return
+}
+
+// parseFilename will parse the timestamp from a log filename (E.g. name.timestamp.log, name.timestamp.log.gz,
+// name.timestamp.hash.log, name.YYYY-MM-DD.log, name.YYYY-MM-DD.N.log or
+// name.YYYY-MM-DD-HH.log)
+func parseFilename(filename, name string) (ts time.Time, ok bool) {
+	// Ensure filename begins with our name and a trailing period
+	if !strings.HasPrefix(filename, name+".") {
+		return
+	}
+
+	// Trim name from filename
+	stamp := filename[len(name)+1:]
+	// Trim compressed extension (if it exists)
+	stamp = strings.TrimSuffix(stamp, compressedExt)
+
+	// Ensure the remaining filename is a log file
+	if !strings.HasSuffix(stamp, ".log") {
+		return
+	}
+
+	// Trim log extension
+	stamp = strings.TrimSuffix(stamp, ".log")
+
+	if i := strings.LastIndexByte(stamp, '.'); i != -1 && isFilenameHash(stamp[i+1:]) {
+		// Trim the hash of the file's contents (E.g. name.timestamp.hash.log)
+		stamp = stamp[:i]
+	}
+
+	if unix, err := strconv.ParseInt(stamp, 10, 64); err == nil {
+		// Stamp is a unix timestamp
+		return time.Unix(0, unix), true
+	}
+
+	if date, ok := parseDatedStamp(stamp, dateLayout); ok {
+		// Stamp is a date (E.g. from midnight rotation)
+		return date, true
+	}
+
+	if hour, ok := parseDatedStamp(stamp, hourLayout); ok {
+		// Stamp is a date and hour (E.g. from hourly rotation)
+		return hour, true
+	}
+
+	return
+}
+
+// Files will return the full paths of the log files for this logger, sorted by timestamp (oldest first)
+// Note: This includes the currently opened file and any compressed files
+func (l *Logger) Files() (filenames []string, err error) {
+	// Acquire lock
+	l.mu.Lock()
+	dir, name, discard := l.dir, l.name, l.discard
+	// Release lock, the directory is read without holding the lock
+	l.mu.Unlock()
+
+	if discard {
+		// Discard loggers do not produce any files, return
+		return
+	}
+
+	var files []logFile
+	if files, err = listFiles(dir, name); err != nil {
+		return
+	}
+
+	for _, file := range files {
+		filenames = append(filenames, file.filename)
+	}
+
+	return
+}
+
+// Dir will return the directory of the logger
+func (l *Logger) Dir() string {
+	return l.dir
+}
+
+// Name will return the name of the logger
+func (l *Logger) Name() string {
+	return l.publicName.Load()
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestFiles(t *testing.T) {
+	var (
+		l *Logger
+
+		filenames []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	// Create files which should not be included
+	for _, filename := range []string{"other.1.log", testName + ".abc.log", testName + ".1.txt"} {
+		if err = ioutil.WriteFile(path.Join(testDir, filename), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.SetNumLines(1)
+
+	var expected []string
+	for i := 0; i < 3; i++ {
+		expected = append(expected, l.f.Name())
+		if err = l.LogString("#1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected = append(expected, l.f.Name())
+
+	if filenames, err = l.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(filenames) != len(expected) {
+		t.Fatalf("invalid number of files, expected %d and received %d", len(expected), len(filenames))
+	}
+
+	for i, filename := range filenames {
+		if filename != expected[i] {
+			t.Fatalf("invalid filename, expected \"%s\" and received \"%s\"", expected[i], filename)
+		}
+	}
+}
+
+func TestFiles_empty(t *testing.T) {
+	var (
+		filenames []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	l := &Logger{dir: testDir, name: testName}
+	if filenames, err = l.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	if filenames != nil {
+		t.Fatalf("expected nil slice, received %v", filenames)
+	}
+
+	l.dir = path.Join(testDir, "missing")
+	if _, err = l.Files(); err == nil {
+		t.Fatal("expected error for missing directory")
+	}
+}
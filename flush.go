@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+// flushLoop will flush the buffer on each tick until the quit channel is closed or the logger is closed
+func (l *Logger) flushLoop(interval time.Duration, quit <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			switch err := l.Flush(); err {
+			case nil:
+			case errors.ErrIsClosed:
+				// Instance of logger is closed, we can bail out completely
+				return
+
+			default:
+				// We encountered an unexpected error, pass to the error handler
+				l.handleError(fmt.Errorf("error flushing file: %v", err))
+			}
+
+		case <-quit:
+			return
+		}
+	}
+}
+
+// stopFlushLoop will stop the flush loop (if set)
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) stopFlushLoop() {
+	if l.flushQuit == nil {
+		return
+	}
+
+	close(l.flushQuit)
+	l.flushQuit = nil
+}
+
+// SetFlushInterval will set the interval in which the buffer is automatically flushed to disk
+// Note: Calling this when a flush interval is already set will replace the existing interval
+func (l *Logger) SetFlushInterval(interval time.Duration) (err error) {
+	// Ensure interval isn't set to zero
+	if interval <= 0 {
+		return ErrInvalidFlushInterval
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	// Stop existing flush loop (if set)
+	l.stopFlushLoop()
+	// Start a new flush loop with the provided interval
+	l.flushQuit = make(chan struct{})
+	go l.flushLoop(interval, l.flushQuit)
+	return
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestFlushInterval(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetFlushInterval(0); err != ErrInvalidFlushInterval {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidFlushInterval, err)
+	}
+
+	if err = l.SetFlushInterval(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace the hour long interval with a short one
+	if err = l.SetFlushInterval(time.Millisecond * 10); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	for i := 0; i < 100; i++ {
+		var bs []byte
+		if bs, err = ioutil.ReadFile(filename); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(bs) > 0 {
+			return
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	t.Fatal("expected buffer to be flushed")
+}
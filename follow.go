@@ -0,0 +1,298 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+// followInterval is the interval at which FollowLatest polls the current file for new entries
+const followInterval = time.Millisecond * 50
+
+// currentFilename will return the name of the current file
+func (l *Logger) currentFilename() (filename string, err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return "", errors.ErrIsClosed
+	}
+
+	if l.discard || l.f == nil {
+		// Logger does not have a current file, return
+		return "", ErrNoCurrentFile
+	}
+
+	if l.tmpFilename != "" {
+		// Current file has not been moved into place yet
+		return l.tmpFilename, nil
+	}
+
+	return l.filename, nil
+}
+
+// newFollower will return a new follower positioned at the end of the logger's current file, or at the beginning when
+// fromStart is set
+func newFollower(l *Logger, fromStart bool) (fp *follower, err error) {
+	var filename string
+	if filename, err = l.currentFilename(); err != nil {
+		return
+	}
+
+	var f follower
+	f.l = l
+	if err = f.open(filename); err != nil {
+		return
+	}
+
+	if fromStart {
+		return &f, nil
+	}
+
+	// Only entries written after the follower is created are followed
+	if f.offset, err = f.f.Seek(0, io.SeekEnd); err != nil {
+		f.f.Close()
+		return
+	}
+
+	return &f, nil
+}
+
+// follower follows the current file of a logger across rotations
+type follower struct {
+	l *Logger
+
+	// Currently followed file
+	f *os.File
+	r *bufio.Reader
+	// Timestamp of the followed file, zero if the filename could not be parsed
+	ts time.Time
+	// Number of bytes read from the current file
+	offset int64
+	// Incomplete line at the end of the current file
+	partial []byte
+}
+
+// open will open the provided file as the followed file
+func (f *follower) open(filename string) (err error) {
+	var file *os.File
+	if file, err = os.Open(filename); err != nil {
+		return
+	}
+
+	f.f = file
+	f.r = bufio.NewReader(file)
+	// Note: Timestamps are used to find the next file, see nextFilename
+	f.ts, _ = parseFilename(strings.TrimSuffix(path.Base(filename), tmpExt), f.l.Name())
+	f.offset = 0
+	f.partial = nil
+	return
+}
+
+// run will call fn with each new line (without the trailing newline) until the context is cancelled or fn returns an
+// error
+func (f *follower) run(ctx context.Context, fn func(line []byte) error) (err error) {
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+
+	for {
+		if err = f.poll(fn); err != nil {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// poll will read any new lines, switching to the logger's current file when the followed file has been rotated
+func (f *follower) poll(fn func(line []byte) error) (err error) {
+	if err = f.read(fn); err != nil {
+		return
+	}
+
+	var rotated bool
+	if rotated, err = f.checkRotation(); err == errors.ErrIsClosed {
+		// Logger has been closed, read the entries flushed on close before returning
+		if rerr := f.read(fn); rerr != nil {
+			return rerr
+		}
+
+		return
+	} else if err != nil || !rotated {
+		return
+	}
+
+	// Read the remainder of the rotated file, as entries may have been written since the previous read
+	if err = f.read(fn); err != nil {
+		return
+	}
+
+	var filename string
+	if filename, err = f.nextFilename(); err != nil {
+		return
+	}
+
+	f.f.Close()
+	if err = f.open(filename); err != nil {
+		return
+	}
+
+	// Read the new file from the beginning
+	return f.read(fn)
+}
+
+// nextFilename will return the file which followed the followed file, so no files are skipped when the logger has
+// rotated more than once since the previous poll
+// Note: If the next file cannot be determined (E.g. the file was not named by the logger), the current file is used
+func (f *follower) nextFilename() (filename string, err error) {
+	if !f.ts.IsZero() {
+		var filenames []string
+		if filenames, err = f.l.Files(); err != nil {
+			return
+		}
+
+		name := f.l.Name()
+		for _, filename = range filenames {
+			if strings.HasSuffix(filename, compressedExt) {
+				// Compressed files are not followed
+				continue
+			}
+
+			if ts, ok := parseFilename(path.Base(filename), name); ok && ts.After(f.ts) {
+				return
+			}
+		}
+	}
+
+	// No newer files exist (E.g. the next file is a temporary file), follow the current file
+	return f.l.currentFilename()
+}
+
+// checkRotation will return whether or not the logger has moved on from the followed file
+// Note: If the followed file has been truncated in place (see RotateModeTruncate), it is read from the beginning
+func (f *follower) checkRotation() (rotated bool, err error) {
+	var info os.FileInfo
+	if info, err = f.f.Stat(); err != nil {
+		return
+	}
+
+	if info.Size() < f.offset {
+		// File has shrunk, read it again from the beginning
+		if _, err = f.f.Seek(0, io.SeekStart); err != nil {
+			return
+		}
+
+		f.r.Reset(f.f)
+		f.offset = 0
+		f.partial = nil
+		return
+	}
+
+	var filename string
+	if filename, err = f.l.currentFilename(); err != nil {
+		return
+	}
+
+	var current os.FileInfo
+	if current, err = os.Stat(filename); os.IsNotExist(err) {
+		// Current file has been renamed (E.g. a temporary file moved into place), check again on the next poll
+		return false, nil
+	} else if err != nil {
+		return
+	}
+
+	// Note: The same file may be reached through a new name (E.g. once a temporary file is moved into place)
+	rotated = !os.SameFile(info, current)
+	return
+}
+
+// read will call fn with each complete line which has been written since the previous read
+func (f *follower) read(fn func(line []byte) error) (err error) {
+	for {
+		var line []byte
+		line, err = f.r.ReadBytes('\n')
+		f.offset += int64(len(line))
+		if err == io.EOF {
+			// Retain the incomplete line until the remainder is written
+			f.partial = append(f.partial, line...)
+			return nil
+		} else if err != nil {
+			return
+		}
+
+		if len(f.partial) > 0 {
+			line = append(f.partial, line...)
+			f.partial = nil
+		}
+
+		if err = fn(bytes.TrimSuffix(line, newline)); err != nil {
+			return
+		}
+	}
+}
+
+// close will close the followed file
+func (f *follower) close() error {
+	return f.f.Close()
+}
+
+// FollowLatest will send each entry written to the current file to the returned entry channel, following the logger
+// across rotations (similar to tail -F)
+// Note: The current file is polled for new entries, so entries are received once they have been flushed (see
+// SetFlushInterval). Entries written before FollowLatest is called are not sent. Entries are sent in order and never
+// dropped, a slow consumer blocks the follower. Both channels are closed once the context is cancelled or an error has
+// occurred, at most one error (E.g. the context's error, or errors.ErrIsClosed once the logger has been closed) is
+// sent before the channels are closed
+func (l *Logger) FollowLatest(ctx context.Context) (<-chan Entry, <-chan error) {
+	entries := make(chan Entry)
+	errs := make(chan error, 1)
+
+	f, err := newFollower(l, false)
+	if err != nil {
+		errs <- err
+		close(entries)
+		close(errs)
+		return entries, errs
+	}
+
+	go func() {
+		defer close(entries)
+		defer close(errs)
+		defer f.close()
+
+		err := f.run(ctx, func(line []byte) (err error) {
+			var e Entry
+			if e, err = parseEntry(line); err != nil {
+				return
+			}
+
+			select {
+			case entries <- e:
+				return
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return entries, errs
+}
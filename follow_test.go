@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hatchify/errors"
+)
+
+func TestFollowLatest(t *testing.T) {
+	var (
+		l   *Logger
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = NewWithOptions(testDir, testName, WithNumLines(100)); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	entries, errs := l.FollowLatest(ctx)
+
+	// Write enough entries to rotate twice
+	written := make(chan error, 1)
+	go func() {
+		for i := 0; i < 250; i++ {
+			if err := l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+				written <- err
+				return
+			}
+
+			if err := l.Flush(); err != nil {
+				written <- err
+				return
+			}
+		}
+
+		written <- nil
+	}()
+
+	for i := 0; i < 250; i++ {
+		var e Entry
+		select {
+		case e = <-entries:
+		case err = <-errs:
+			t.Fatal(err)
+		}
+
+		if expected := fmt.Sprintf("#%d", i+1); e.Message != expected {
+			t.Fatalf("invalid message, expected %s and received %s", expected, e.Message)
+		}
+	}
+
+	if err = <-written; err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	if err = <-errs; err != context.Canceled {
+		t.Fatalf("invalid error, expected %v and received %v", context.Canceled, err)
+	}
+}
+
+func TestFollowLatest_close(t *testing.T) {
+	var (
+		l   *Logger
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, errs := l.FollowLatest(context.Background())
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entry is flushed on close
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if e := <-entries; e.Message != "#1" {
+		t.Fatalf("invalid message, expected %s and received %s", "#1", e.Message)
+	}
+
+	if err = <-errs; err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+}
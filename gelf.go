@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// gelfVersion is the version of the GELF payloads
+	gelfVersion = "1.1"
+	// gelfMaxDatagramSize is the maximum size of a GELF datagram, larger payloads are chunked
+	gelfMaxDatagramSize = 8192
+	// gelfChunkHeaderSize is the size of a chunk header (magic bytes, message ID, sequence number and count)
+	gelfChunkHeaderSize = 12
+	// gelfChunkSize is the size of the payload within each chunk
+	gelfChunkSize = gelfMaxDatagramSize - gelfChunkHeaderSize
+	// gelfMaxChunks is the maximum number of chunks a message can be split into
+	gelfMaxChunks = 128
+)
+
+// gelfMagic is the magic bytes which begin each GELF chunk
+var gelfMagic = []byte{0x1e, 0x0f}
+
+// gelfLevels are the syslog severities of each level
+var gelfLevels = [...]int{
+	LevelDebug: 7,
+	LevelInfo:  6,
+	LevelWarn:  4,
+	LevelError: 3,
+	LevelFatal: 2,
+}
+
+// NewGELFWriter will return a writer which sends entries as GELF 1.1 payloads to a Graylog UDP input
+// Note: The returned writer implements io.Closer, which MultiLogger.Close calls when closing its destinations
+func NewGELFWriter(host string, port int) (w io.Writer, err error) {
+	var g gelfWriter
+	if g.host, err = os.Hostname(); err != nil {
+		return
+	}
+
+	if g.conn, err = net.Dial("udp", net.JoinHostPort(host, strconv.Itoa(port))); err != nil {
+		return
+	}
+
+	return &g, nil
+}
+
+// gelfWriter sends log lines as GELF payloads over UDP
+type gelfWriter struct {
+	conn net.Conn
+	// Hostname of the current machine, used as the GELF host
+	host string
+}
+
+// Write will send each line within bs as a GELF payload
+func (g *gelfWriter) Write(bs []byte) (n int, err error) {
+	return writeLines(bs, g.writeLine)
+}
+
+// writeLine will send a single log line as a GELF payload
+// Note: Lines which cannot be parsed are sent as is with the current time and an informational level
+func (g *gelfWriter) writeLine(line []byte) (err error) {
+	e, err := parseEntry(line)
+	if err != nil {
+		e = Entry{Timestamp: time.Now().UnixNano(), Message: string(line)}
+	}
+
+	var payload []byte
+	if payload, err = json.Marshal(g.newPayload(e)); err != nil {
+		return
+	}
+
+	if len(payload) <= gelfMaxDatagramSize {
+		_, err = g.conn.Write(payload)
+		return
+	}
+
+	return g.writeChunked(payload)
+}
+
+// newPayload will return the GELF payload of an entry
+func (g *gelfWriter) newPayload(e Entry) (payload map[string]interface{}) {
+	level := gelfLevels[LevelInfo]
+	if l, ok := levelFromName(e.Level); ok {
+		level = gelfLevels[l]
+	}
+
+	payload = map[string]interface{}{
+		"version":       gelfVersion,
+		"host":          g.host,
+		"short_message": e.Message,
+		"timestamp":     float64(e.Timestamp) / float64(time.Second),
+		"level":         level,
+	}
+
+	if e.PID > 0 {
+		payload["_pid"] = e.PID
+	}
+
+	if e.Caller != "" {
+		payload["_caller"] = e.Caller
+	}
+
+	if e.Stack != "" {
+		payload["_stack"] = e.Stack
+	}
+
+	for key, value := range e.Extra {
+		if key == "id" {
+			// The _id field is reserved by GELF, continue
+			continue
+		}
+
+		payload["_"+key] = value
+	}
+
+	return
+}
+
+// writeChunked will send a payload as GELF chunks
+func (g *gelfWriter) writeChunked(payload []byte) (err error) {
+	count := (len(payload) + gelfChunkSize - 1) / gelfChunkSize
+	if count > gelfMaxChunks {
+		return ErrGELFMessageTooLarge
+	}
+
+	// Each chunk of a message shares a random message ID
+	id := make([]byte, 8)
+	if _, err = rand.Read(id); err != nil {
+		return
+	}
+
+	chunk := make([]byte, 0, gelfMaxDatagramSize)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * gelfChunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+
+		chunk = append(chunk[:0], gelfMagic...)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, payload[i*gelfChunkSize:end]...)
+		if _, err = g.conn.Write(chunk); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Close will close the UDP connection
+func (g *gelfWriter) Close() error {
+	return g.conn.Close()
+}
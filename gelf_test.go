@@ -0,0 +1,155 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewGELFWriter(t *testing.T) {
+	var (
+		l    *Logger
+		w    io.Writer
+		conn *net.UDPConn
+
+		payload map[string]interface{}
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	// Start a fake Graylog UDP input
+	if conn, err = net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	addr := conn.LocalAddr().(*net.UDPAddr)
+	if w, err = NewGELFWriter(addr.IP.String(), addr.Port); err != nil {
+		t.Fatal(err)
+	}
+	defer w.(io.Closer).Close()
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetTee(w); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if err = l.Warn([]byte("hello world")); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload, err = readGELFPayload(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	hostname, _ := os.Hostname()
+	expected := map[string]interface{}{
+		"version":       "1.1",
+		"host":          hostname,
+		"short_message": "hello world",
+		"level":         float64(4),
+	}
+
+	for key, value := range expected {
+		if payload[key] != value {
+			t.Fatalf("invalid %s, expected %v and received %v", key, value, payload[key])
+		}
+	}
+
+	ts, ok := payload["timestamp"].(float64)
+	if !ok {
+		t.Fatalf("invalid timestamp, received %v", payload["timestamp"])
+	}
+
+	if delta := ts - float64(start.UnixNano())/float64(time.Second); delta < -1 || delta > 1 {
+		t.Fatalf("invalid timestamp, expected %v and received %v", start.Unix(), ts)
+	}
+}
+
+func TestNewGELFWriter_chunked(t *testing.T) {
+	var (
+		w    io.Writer
+		conn *net.UDPConn
+
+		payload map[string]interface{}
+
+		err error
+	)
+
+	if conn, err = net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	addr := conn.LocalAddr().(*net.UDPAddr)
+	if w, err = NewGELFWriter(addr.IP.String(), addr.Port); err != nil {
+		t.Fatal(err)
+	}
+	defer w.(io.Closer).Close()
+
+	msg := strings.Repeat("a", 20000)
+	if _, err = w.Write([]byte("1@" + msg + "\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload, err = readGELFPayload(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload["short_message"] != msg {
+		t.Fatal("invalid short_message, expected chunks to be reassembled into the original message")
+	}
+}
+
+// readGELFPayload will read a GELF payload from the connection, reassembling chunks when the payload is chunked
+func readGELFPayload(conn *net.UDPConn) (payload map[string]interface{}, err error) {
+	var (
+		chunks [][]byte
+		buf    = make([]byte, gelfMaxDatagramSize)
+	)
+
+	for {
+		if err = conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+			return
+		}
+
+		var n int
+		if n, err = conn.Read(buf); err != nil {
+			return
+		}
+
+		datagram := append([]byte(nil), buf[:n]...)
+		if !bytes.HasPrefix(datagram, gelfMagic) {
+			err = json.Unmarshal(datagram, &payload)
+			return
+		}
+
+		seq, count := int(datagram[10]), int(datagram[11])
+		if chunks == nil {
+			chunks = make([][]byte, count)
+		}
+
+		chunks[seq] = datagram[gelfChunkHeaderSize:]
+		if seq == count-1 {
+			break
+		}
+	}
+
+	err = json.Unmarshal(bytes.Join(chunks, nil), &payload)
+	return
+}
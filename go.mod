@@ -3,6 +3,14 @@ module github.com/gdbu/logger
 go 1.14
 
 require (
+	github.com/Shopify/sarama v1.29.1
+	github.com/aws/aws-sdk-go-v2 v1.17.8
+	github.com/aws/aws-sdk-go-v2/service/s3 v1.31.3
 	github.com/gdbu/atoms v1.0.1
+	github.com/go-logfmt/logfmt v0.5.1
 	github.com/hatchify/errors v0.4.82
+	github.com/prometheus/client_golang v1.11.1
+	github.com/sirupsen/logrus v1.8.1
+	go.opentelemetry.io/otel/trace v0.20.0
+	go.uber.org/zap v1.21.0
 )
@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"encoding/hex"
+	"os"
+	"strings"
+
+	"github.com/hatchify/errors"
+)
+
+// filenameHashLength is the number of hex characters of the SHA-256 hash included in filenames
+const filenameHashLength = 8
+
+// renameWithHash will rename the provided file to include the truncated hash of its contents before the log extension
+// (E.g. name.<ts>.log becomes name.<ts>.<hash>.log), returning the new filename
+func renameWithHash(filename string) (hashed string, err error) {
+	var hash []byte
+	if hash, err = hashFile(filename); err != nil {
+		return
+	}
+
+	hashed = strings.TrimSuffix(filename, ".log") + "." + string(hash[:filenameHashLength]) + ".log"
+	if err = os.Rename(filename, hashed); err != nil {
+		return
+	}
+
+	return
+}
+
+// isFilenameHash will return whether or not the provided string is a truncated hash included in a filename
+func isFilenameHash(str string) bool {
+	if len(str) != filenameHashLength {
+		return false
+	}
+
+	_, err := hex.DecodeString(str)
+	return err == nil
+}
+
+// SetHashInFilename will set whether or not closed files are renamed to include the hash of their contents
+// Note: This changes the filename format and is disabled by default. Files are written as name.<ts>.log and, once
+// closed, renamed to name.<ts>.<hash>.log where <hash> is the first 8 hex characters of the SHA-256 hash of the file.
+// Tools which match the default name.<ts>.log format must be updated to handle the additional segment. The rotation
+// hook, manifest and compression receive the renamed file. Files rotated in place (see RotateModeTruncate) are not
+// renamed
+func (l *Logger) SetHashInFilename(hashInFilename bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.hashInFilename = hashInFilename
+	return
+}
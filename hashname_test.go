@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestSetHashInFilename(t *testing.T) {
+	var (
+		l     *Logger
+		files []string
+		err   error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = NewWithOptions(testDir, testName, WithNumLines(10), WithHashInFilename(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 25; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if files, err = l.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 3 {
+		t.Fatalf("invalid number of files, expected %d and received %d", 3, len(files))
+	}
+
+	for _, filename := range files {
+		// Filenames are formatted as name.<ts>.<hash>.log
+		parts := strings.Split(path.Base(filename), ".")
+		if len(parts) != 4 {
+			t.Fatalf("invalid filename, expected name.<ts>.<hash>.log and received %s", filename)
+		}
+
+		var hash []byte
+		if hash, err = hashFile(filename); err != nil {
+			t.Fatal(err)
+		}
+
+		if expected := string(hash[:filenameHashLength]); parts[2] != expected {
+			t.Fatalf("invalid hash, expected %s and received %s", expected, parts[2])
+		}
+	}
+}
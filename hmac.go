@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+
+	"github.com/hatchify/errors"
+)
+
+// hmacHexLength is the length of a hex-encoded HMAC-SHA256
+const hmacHexLength = sha256.Size * 2
+
+// appendHMAC will append the separator and hex-encoded HMAC of the entry line (all bytes of buf from start)
+func appendHMAC(buf []byte, start int, key []byte, sep byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(buf[start:])
+	sum := mac.Sum(nil)
+
+	buf = append(buf, sep)
+	n := len(buf)
+	buf = append(buf, make([]byte, hmacHexLength)...)
+	hex.Encode(buf[n:], sum)
+	return buf
+}
+
+// appendLengthPrefixedHMAC will append the separator and hex-encoded HMAC of a length-prefixed entry (E.g. MessagePack),
+// updating the length prefix to include the HMAC
+func appendLengthPrefixedHMAC(buf, key []byte, sep byte) []byte {
+	buf = appendHMAC(buf, lengthPrefixSize, key, sep)
+	binary.BigEndian.PutUint32(buf, uint32(len(buf)-lengthPrefixSize))
+	return buf
+}
+
+// splitHMAC will split an entry line into its contents and hex-encoded HMAC
+// Note: The HMAC is the trailing 64 characters, preceded by the separator
+func splitHMAC(line []byte) (contents, sum []byte, ok bool) {
+	line = bytes.TrimSuffix(line, newline)
+	if len(line) <= hmacHexLength {
+		return
+	}
+
+	end := len(line) - hmacHexLength - 1
+	return line[:end], line[end+1:], true
+}
+
+// VerifyEntry will return whether or not the HMAC of the provided entry line matches the line's contents
+// Note: The HMAC is computed over the line preceding the final separator (E.g. timestamp@message for entries without a
+// level), see SetHMACKey
+func VerifyEntry(entry, key []byte) bool {
+	contents, sum, ok := splitHMAC(entry)
+	if !ok {
+		return false
+	}
+
+	expected := make([]byte, hex.DecodedLen(len(sum)))
+	if _, err := hex.Decode(expected, sum); err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(contents)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// OpenWithHMACKey will open a log file for reading, verifying the HMAC of each entry with the provided key
+// Note: Next returns ErrInvalidHMAC for entries which do not match their HMAC (E.g. tampered entries, or a wrong key),
+// subsequent calls will continue with the following entry
+func OpenWithHMACKey(filename string, key []byte) (rp *Reader, err error) {
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+
+	rp = newReader(f)
+	rp.hmacKey = append([]byte(nil), key...)
+	return
+}
+
+// verifyHMAC will verify the HMAC of the provided line, returning the line without its HMAC
+func (r *Reader) verifyHMAC(line []byte) (contents []byte, err error) {
+	if !VerifyEntry(line, r.hmacKey) {
+		return nil, ErrInvalidHMAC
+	}
+
+	contents, _, _ = splitHMAC(line)
+	return
+}
+
+// SetHMACKey will set the key used to sign each entry with an HMAC-SHA256, an empty key disables signing
+// Note: The hex-encoded HMAC of each entry line is appended after a separator (E.g. timestamp@message@hmac), allowing
+// tampered entries to be detected with VerifyEntry or OpenWithHMACKey. Every format is signed the same way, the HMAC
+// follows the JSON object, logfmt pairs or CSV row (including the header row) of each line, and follows the map within
+// the length prefix of MessagePack entries. Signed files should be read with OpenWithHMACKey
+func (l *Logger) SetHMACKey(key []byte) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	if len(key) == 0 {
+		l.hmacKey = nil
+		return
+	}
+
+	// Copy key, so the caller may re-use the provided slice
+	l.hmacKey = append([]byte(nil), key...)
+	return
+}
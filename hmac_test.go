@@ -0,0 +1,171 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestSetHMACKey(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+		e Entry
+
+		bs  []byte
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	key := []byte("secret")
+	if l, err = NewWithOptions(testDir, testName, WithHMACKey(key)); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	for i := 0; i < 3; i++ {
+		if err = l.Info([]byte(fmt.Sprintf("#%d", i+1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if bs, err = ioutil.ReadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := bytes.Split(bytes.TrimSuffix(bs, newline), newline)
+	for _, line := range lines {
+		if !VerifyEntry(line, key) {
+			t.Fatalf("invalid entry, expected %q to be verified", line)
+		}
+
+		if VerifyEntry(line, []byte("wrong")) {
+			t.Fatalf("invalid entry, expected %q not to be verified with the wrong key", line)
+		}
+	}
+
+	if r, err = OpenWithHMACKey(filename, key); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if e, err = r.Next(); err != nil {
+			t.Fatal(err)
+		}
+
+		if expected := fmt.Sprintf("#%d", i+1); e.Message != expected {
+			t.Fatalf("invalid message, expected %s and received %s", expected, e.Message)
+		}
+	}
+
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tamper with the second entry
+	tampered := bytes.Replace(bs, []byte("#2"), []byte("#9"), 1)
+	if err = ioutil.WriteFile(filename, tampered, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = OpenWithHMACKey(filename, key); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for i, expected := range []error{nil, ErrInvalidHMAC, nil, io.EOF} {
+		if _, err = r.Next(); err != expected {
+			t.Fatalf("invalid error for entry %d, expected %v and received %v", i+1, expected, err)
+		}
+	}
+}
+
+func TestSetHMACKey_formats(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+		e Entry
+
+		bs  []byte
+		err error
+	)
+
+	key := []byte("secret")
+	formats := map[string]Option{
+		"json":        WithJSON(true),
+		"logfmt":      WithLogfmt(true),
+		"csv":         WithCSV(true),
+		"compact":     WithCompactMode(true),
+		"messagepack": WithMessagePack(true),
+	}
+
+	for name, opt := range formats {
+		if err = os.MkdirAll(testDir, 0744); err != nil {
+			t.Fatal(err)
+		}
+
+		if l, err = NewWithOptions(testDir, testName, WithHMACKey(key), opt); err != nil {
+			t.Fatal(err)
+		}
+
+		filename := l.f.Name()
+		for i := 0; i < 2; i++ {
+			if err = l.Info([]byte(fmt.Sprintf("#%d", i+1))); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err = l.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if name != "csv" && name != "compact" {
+			// Entries are verified and parsed by the reader
+			if r, err = OpenWithHMACKey(filename, key); err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < 2; i++ {
+				if e, err = r.Next(); err != nil {
+					t.Fatal(err)
+				}
+
+				if expected := fmt.Sprintf("#%d", i+1); e.Message != expected {
+					t.Fatalf("invalid message, expected %s and received %s", expected, e.Message)
+				}
+			}
+
+			if err = r.Close(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if name != "messagepack" {
+			// Each line is signed, including the CSV header row
+			if bs, err = ioutil.ReadFile(filename); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, line := range bytes.Split(bytes.TrimSuffix(bs, newline), newline) {
+				if !VerifyEntry(line, key) {
+					t.Fatalf("invalid %s entry, expected %q to be verified", name, line)
+				}
+			}
+		}
+
+		if err = os.RemoveAll(testDir); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
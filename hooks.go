@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"bytes"
+
+	"github.com/hatchify/errors"
+)
+
+// applyHooks will pass the message through each hook in order, escaping the result when newline escaping is enabled
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) applyHooks(msg []byte) (out []byte, err error) {
+	out = msg
+	for _, h := range l.hooks {
+		if out, err = h(out); err != nil {
+			return
+		}
+	}
+
+	switch {
+	case l.base64Messages.Get():
+		// Base64 encoding is enabled, the message is encoded when formatted
+	case l.escapeNewlines.Get():
+		// Newline escaping is enabled, escape the message once all hooks have been applied
+		out = escapeNewlines(out)
+	case len(l.hooks) == 0:
+		// No hooks are set, message was validated before the lock was acquired
+	case bytes.Index(out, newline) > -1:
+		// Hook produced a message containing a newline, return
+		err = ErrMessageContainsNewline
+	}
+
+	return
+}
+
+// AddHook will append a hook to be called with each message before it is written
+// Note: Hooks are called in the order they were added. Hooks must not modify the provided message in place, as it may
+// reference the caller's buffer
+func (l *Logger) AddHook(h Hook) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.hooks = append(l.hooks, h)
+	return
+}
+
+// RemoveHooks will remove all hooks
+func (l *Logger) RemoveHooks() {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	l.hooks = nil
+}
@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+func TestAddHook(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	creditCard := regexp.MustCompile(`\b\d(?:[ -]?\d){12,15}\b`)
+	scrub := func(msg []byte) ([]byte, error) {
+		return creditCard.ReplaceAll(msg, []byte("***")), nil
+	}
+
+	requestID := func(msg []byte) ([]byte, error) {
+		return append([]byte("[req-1] "), msg...), nil
+	}
+
+	errTestHook := errors.Error("test hook error")
+	if err = l.AddHook(scrub); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.AddHook(requestID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("charged card 4111 1111 1111 1111 for $10"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("charged card 5500-0000-0000-0004"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.AddHook(func(msg []byte) ([]byte, error) { return nil, errTestHook }); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("aborted"); err != errTestHook {
+		t.Fatalf("invalid error, expected %v and received %v", errTestHook, err)
+	}
+
+	l.RemoveHooks()
+
+	if err = l.LogString("card 4111111111111111"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"[req-1] charged card *** for $10",
+		"[req-1] charged card ***",
+		"card 4111111111111111",
+	}
+
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
+
+func TestAddHook_newline(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.AddHook(func(msg []byte) ([]byte, error) {
+		return append(msg, '\n'), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("hello world"); err != ErrMessageContainsNewline {
+		t.Fatalf("invalid error, expected %v and received %v", ErrMessageContainsNewline, err)
+	}
+}
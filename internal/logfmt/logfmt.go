@@ -0,0 +1,66 @@
+// Package logfmt provides the logfmt value encoding shared by the logger and its adapters
+package logfmt
+
+import "unicode/utf8"
+
+const hexDigits = "0123456789abcdef"
+
+// AppendValue will append a logfmt value to the provided buffer, quoting the value when required (E.g. values
+// containing a space, '=', '"', a backslash or a control character)
+// Note: Quoted values escape quotes, backslashes and control characters, so the value never contains a newline
+func AppendValue(buf []byte, value string) []byte {
+	if !needsQuote(value) {
+		return append(buf, value...)
+	}
+
+	buf = append(buf, '"')
+	for i := 0; i < len(value); {
+		c := value[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(value[i:])
+			if r == utf8.RuneError && size == 1 {
+				// Invalid UTF-8, replace with the replacement character
+				buf = append(buf, "\ufffd"...)
+			} else {
+				buf = append(buf, value[i:i+size]...)
+			}
+
+			i += size
+			continue
+		}
+
+		switch c {
+		case '"', '\\':
+			buf = append(buf, '\\', c)
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\r':
+			buf = append(buf, '\\', 'r')
+		case '\t':
+			buf = append(buf, '\\', 't')
+		default:
+			if c < ' ' || c == 0x7f {
+				// Control character, escape as a unicode sequence
+				buf = append(buf, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			} else {
+				buf = append(buf, c)
+			}
+		}
+
+		i++
+	}
+
+	return append(buf, '"')
+}
+
+// needsQuote will return whether or not a logfmt value must be quoted
+func needsQuote(value string) bool {
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c <= ' ' || c == '=' || c == '"' || c == '\\' || c >= 0x7f {
+			return true
+		}
+	}
+
+	return false
+}
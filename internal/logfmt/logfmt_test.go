@@ -0,0 +1,20 @@
+package logfmt
+
+import "testing"
+
+func TestAppendValue(t *testing.T) {
+	tests := map[string]string{
+		"":                 "",
+		"simple":           "simple",
+		"two words":        `"two words"`,
+		"a=b":              `"a=b"`,
+		`say "hi"`:         `"say \"hi\""`,
+		"tab\tcontrol\x01": `"tab\tcontrol\u0001"`,
+	}
+
+	for value, expected := range tests {
+		if encoded := string(AppendValue(nil, value)); encoded != expected {
+			t.Fatalf("invalid value, expected %s and received %s", expected, encoded)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// newJSONEntry will return a new JSON entry
+func newJSONEntry(e entry) (je jsonEntry) {
+	je.TS = time.Now().UnixNano()
+	je.Level = e.level.name()
+	je.Hostname = e.hostname
+	je.PID = e.pid
+	je.Caller = e.caller
+	je.Msg = string(e.prefix) + string(e.msg)
+	je.Stack = string(e.stack)
+	return
+}
+
+// jsonEntry represents a log entry written in the JSON format
+type jsonEntry struct {
+	// Unix timestamp (in nanoseconds)
+	TS int64 `json:"ts"`
+	// Level name, omitted for messages without a level
+	Level string `json:"level,omitempty"`
+	// Hostname, omitted when hostname inclusion is not enabled
+	Hostname string `json:"hostname,omitempty"`
+	// Process ID, omitted when PID inclusion is not enabled
+	PID int `json:"pid,omitempty"`
+	// Caller file and line, omitted when caller depth is not set
+	Caller string `json:"caller,omitempty"`
+	// Log message
+	Msg string `json:"msg"`
+	// Condensed stack trace, omitted when stack capture is not enabled for the level
+	Stack string `json:"stack,omitempty"`
+}
+
+// appendJSONEntry will append the message as a JSON object to the provided buffer
+func appendJSONEntry(buf []byte, e entry) (out []byte, err error) {
+	var bs []byte
+	// Marshal entry, json.Marshal produces compact output which never contains a newline
+	if bs, err = json.Marshal(newJSONEntry(e)); err != nil {
+		return
+	}
+
+	if len(e.fields) == 0 && len(e.attrs) == 0 {
+		out = append(buf, bs...)
+		return
+	}
+
+	// Trim closing brace so fields and attributes follow the core fields
+	out = append(buf, bs[:len(bs)-1]...)
+	for _, f := range e.fields {
+		out = append(out, ',')
+		if out, err = appendJSONString(out, f.key); err != nil {
+			return
+		}
+
+		out = append(out, ':')
+		if out, err = appendJSONString(out, f.value); err != nil {
+			return
+		}
+	}
+
+	out = append(out, e.attrs...)
+	out = append(out, '}')
+	return
+}
+
+// appendJSONString will append the provided string as a JSON string to the provided buffer
+func appendJSONString(buf []byte, str string) (out []byte, err error) {
+	var bs []byte
+	if bs, err = json.Marshal(str); err != nil {
+		return
+	}
+
+	out = append(buf, bs...)
+	return
+}
+
+// parseJSONEntry will parse a JSON log line into an Entry
+// Note: Unrecognized keys are stored within Extra, non-string values are stored as their raw JSON
+func parseJSONEntry(line []byte) (e Entry, err error) {
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(line, &fields); err != nil {
+		return
+	}
+
+	for key, raw := range fields {
+		value := string(raw)
+		if len(raw) > 0 && raw[0] == '"' {
+			// Value is a string, decode value
+			if err = json.Unmarshal(raw, &value); err != nil {
+				return
+			}
+		}
+
+		if err = e.setField(key, value); err != nil {
+			return
+		}
+	}
+
+	return
+}
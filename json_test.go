@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestUseJSON(t *testing.T) {
+	var (
+		l *Logger
+		f *os.File
+
+		entries []jsonEntry
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.UseJSON(true)
+
+	if err = l.Info([]byte("hello \"world\"")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("no level"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("bad\nmessage"); err != ErrMessageContainsNewline {
+		t.Fatalf("invalid error, expected %v and received %v", ErrMessageContainsNewline, err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if f, err = os.Open(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var e jsonEntry
+		if err = json.Unmarshal(s.Bytes(), &e); err != nil {
+			t.Fatal(err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 2, len(entries))
+	}
+
+	if e := entries[0]; e.TS == 0 || e.Level != "INFO" || e.Msg != "hello \"world\"" {
+		t.Fatalf("invalid entry: %+v", e)
+	}
+
+	if e := entries[1]; e.TS == 0 || e.Level != "" || e.Msg != "no level" {
+		t.Fatalf("invalid entry: %+v", e)
+	}
+}
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/hatchify/errors"
+)
+
+const (
+	// latestSymlinkExt is the extension of the symlink which points to the current log file
+	latestSymlinkExt = ".latest.log"
+	// latestTextExt is the extension of the fallback file containing the path of the current log file
+	latestTextExt = ".latest.txt"
+)
+
+// getLatestSymlink will return the path of the latest symlink (E.g. dir/name.latest.log)
+func (l *Logger) getLatestSymlink() string {
+	return path.Join(l.dir, l.name+latestSymlinkExt)
+}
+
+// getLatestText will return the path of the latest fallback file (E.g. dir/name.latest.txt)
+func (l *Logger) getLatestText() string {
+	return path.Join(l.dir, l.name+latestTextExt)
+}
+
+// updateLatest will point the latest symlink to the current file
+// Note: If symlinks are not supported (E.g. Windows without elevated privileges), the path of the current file is
+// written to dir/name.latest.txt and a warning is passed to the error handler
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) updateLatest() (err error) {
+	if l.f == nil {
+		// File does not exist (E.g. discard logger), return
+		return
+	}
+
+	symlink := l.getLatestSymlink()
+	tmp := symlink + ".tmp"
+	// Remove any stale temporary symlink
+	os.Remove(tmp)
+
+	// Create a symlink to the current file's base name at the temporary path
+	if err = os.Symlink(filepath.Base(l.filename), tmp); err == nil {
+		// Atomically replace the existing symlink
+		return os.Rename(tmp, symlink)
+	}
+
+	// Symlink could not be created, fall back to writing the path to a text file
+	warning := fmt.Errorf("warning: unable to create latest symlink, falling back to %s: %v", latestTextExt, err)
+	l.addPending(newErrorCall(l.errorHandler(), warning))
+	return ioutil.WriteFile(l.getLatestText(), []byte(l.filename), 0644)
+}
+
+// removeLatest will remove the latest symlink and fallback text file
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) removeLatest() {
+	if l.discard {
+		// Discard loggers do not create a symlink, return
+		return
+	}
+
+	os.Remove(l.getLatestSymlink())
+	os.Remove(l.getLatestText())
+}
+
+// SetLatestSymlink will set whether or not a symlink at dir/name.latest.log points to the current log file
+// Note: The symlink is replaced atomically after each rotation and removed on close
+func (l *Logger) SetLatestSymlink(latestSymlink bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	if l.latestSymlink = latestSymlink; !latestSymlink {
+		// Latest symlink is being disabled, remove the existing symlink
+		l.removeLatest()
+		return
+	}
+
+	// Point the latest symlink to the current file
+	return l.updateLatest()
+}
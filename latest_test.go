@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLatestSymlink(t *testing.T) {
+	var (
+		l *Logger
+		v *Viewer
+
+		target   string
+		logCount int
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetNumLines(1)
+	if err = l.SetLatestSymlink(true); err != nil {
+		t.Fatal(err)
+	}
+
+	symlink := l.getLatestSymlink()
+	if target, err = os.Readlink(symlink); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := filepath.Base(l.f.Name()); target != expected {
+		t.Fatalf("invalid symlink target, expected \"%s\" and received \"%s\"", expected, target)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if target, err = os.Readlink(symlink); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := filepath.Base(l.f.Name()); target != expected {
+		t.Fatalf("invalid symlink target after rotation, expected \"%s\" and received \"%s\"", expected, target)
+	}
+
+	if v, err = NewViewer(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = v.ForEach(func(key string) (err error) {
+		logCount++
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rotated file and current file, the symlink should not be included
+	if logCount != 2 {
+		t.Fatalf("invalid number of logs, expected %d and received %d", 2, logCount)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = os.Lstat(symlink); !os.IsNotExist(err) {
+		t.Fatalf("expected symlink to be removed on close, received %v", err)
+	}
+}
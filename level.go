@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// levelNone is used for messages which are logged without a level (E.g. Log, LogString)
+	levelNone Level = iota - 1
+	// LevelDebug is used for verbose debugging messages
+	LevelDebug
+	// LevelInfo is used for general informational messages
+	LevelInfo
+	// LevelWarn is used for messages which may require attention
+	LevelWarn
+	// LevelError is used for error messages
+	LevelError
+	// LevelFatal is used for messages which precede the process exiting
+	LevelFatal
+)
+
+// levelBytes are the fixed-width level names written to the log files
+var levelBytes = [...][]byte{
+	LevelDebug: []byte("DEBUG"),
+	LevelInfo:  []byte("INFO "),
+	LevelWarn:  []byte("WARN "),
+	LevelError: []byte("ERROR"),
+	LevelFatal: []byte("FATAL"),
+}
+
+// Level represents the severity level of a log message
+type Level int8
+
+// bytes will return the fixed-width name of the level
+func (l Level) bytes() (bs []byte) {
+	if !l.isValid() {
+		return
+	}
+
+	return levelBytes[l]
+}
+
+// name will return the name of the level without padding
+func (l Level) name() (name string) {
+	return string(bytes.TrimRight(l.bytes(), " "))
+}
+
+// isValid will return whether or not the level is a known level
+func (l Level) isValid() bool {
+	return l >= LevelDebug && l <= LevelFatal
+}
+
+// levelShortNames are the short forms of the level names accepted by LevelFromString
+var levelShortNames = [...]string{
+	LevelDebug: "dbg",
+	LevelInfo:  "inf",
+	LevelWarn:  "wrn",
+	LevelError: "err",
+	LevelFatal: "ftl",
+}
+
+// levelFromName will return the level matching the provided name or short name (case-insensitive)
+func levelFromName(name string) (level Level, ok bool) {
+	for i := range levelBytes {
+		if strings.EqualFold(name, Level(i).name()) || strings.EqualFold(name, levelShortNames[i]) {
+			return Level(i), true
+		}
+	}
+
+	return
+}
+
+// LevelFromString will return the level matching the provided name (case-insensitive), accepting both full names
+// (E.g. "debug") and short forms (E.g. "dbg")
+func LevelFromString(s string) (level Level, err error) {
+	var ok bool
+	if level, ok = levelFromName(s); !ok {
+		return level, ErrInvalidLevel
+	}
+
+	return
+}
+
+// String will return the canonical uppercase name of the level (E.g. "DEBUG")
+func (l Level) String() string {
+	if !l.isValid() {
+		return fmt.Sprintf("Level(%d)", int8(l))
+	}
+
+	return l.name()
+}
+
+// MarshalJSON will marshal the level as its name
+func (l Level) MarshalJSON() (bs []byte, err error) {
+	if !l.isValid() {
+		return nil, ErrInvalidLevel
+	}
+
+	return json.Marshal(l.name())
+}
+
+// UnmarshalJSON will unmarshal a level from its name or short name
+func (l *Level) UnmarshalJSON(bs []byte) (err error) {
+	var name string
+	if err = json.Unmarshal(bs, &name); err != nil {
+		return
+	}
+
+	*l, err = LevelFromString(name)
+	return
+}
@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLevel(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetLevel(LevelWarn)
+
+	fns := []func([]byte) error{l.Debug, l.Info, l.Warn, l.Error, l.Log}
+	for i, fn := range fns {
+		if err = fn([]byte(fmt.Sprintf("#%d", i+1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"WARN @#3", "ERROR@#4", "#5"}
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
+
+func TestFatal(t *testing.T) {
+	var (
+		l *Logger
+
+		code int
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	exit = func(c int) { code = c }
+	defer func() { exit = os.Exit }()
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Fatal([]byte("goodbye")); err != nil {
+		t.Fatal(err)
+	}
+
+	if code != 1 {
+		t.Fatalf("invalid exit code, expected %d and received %d", 1, code)
+	}
+
+	if !l.isClosed() {
+		t.Fatal("expected logger to be closed")
+	}
+}
+
+func TestLevelFromString(t *testing.T) {
+	var err error
+	os.Setenv("TEST_LOGGER_DIR", testDir)
+	defer os.Unsetenv("TEST_LOGGER_DIR")
+	os.Setenv("TEST_LOGGER_NAME", testName)
+	defer os.Unsetenv("TEST_LOGGER_NAME")
+	defer os.Unsetenv("TEST_LOGGER_LEVEL")
+
+	for _, level := range []Level{LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal} {
+		names := []string{level.String(), strings.ToLower(level.String()), levelShortNames[level]}
+		for _, name := range names {
+			var parsed Level
+			if parsed, err = LevelFromString(name); err != nil {
+				t.Fatal(err)
+			}
+
+			if parsed != level {
+				t.Fatalf("invalid level for \"%s\", expected %v and received %v", name, level, parsed)
+			}
+		}
+
+		// JSON round-trip
+		var bs []byte
+		if bs, err = json.Marshal(level); err != nil {
+			t.Fatal(err)
+		}
+
+		if expected := `"` + level.String() + `"`; string(bs) != expected {
+			t.Fatalf("invalid JSON, expected %s and received %s", expected, bs)
+		}
+
+		var unmarshaled Level
+		if err = json.Unmarshal(bs, &unmarshaled); err != nil {
+			t.Fatal(err)
+		}
+
+		if unmarshaled != level {
+			t.Fatalf("invalid level, expected %v and received %v", level, unmarshaled)
+		}
+
+		// Environment variable round-trip
+		os.Setenv("TEST_LOGGER_LEVEL", strings.ToLower(level.String()))
+		var cfg Config
+		if cfg, err = ParseConfigFromEnv("TEST_LOGGER"); err != nil {
+			t.Fatal(err)
+		}
+
+		if unmarshaled, err = LevelFromString(cfg.Level); err != nil {
+			t.Fatal(err)
+		}
+
+		if unmarshaled != level {
+			t.Fatalf("invalid level, expected %v and received %v", level, unmarshaled)
+		}
+	}
+
+	if _, err = LevelFromString("verbose"); err != ErrInvalidLevel {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidLevel, err)
+	}
+
+	var level Level
+	if err = json.Unmarshal([]byte(`"verbose"`), &level); err != ErrInvalidLevel {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidLevel, err)
+	}
+}
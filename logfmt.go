@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"github.com/gdbu/logger/internal/logfmt"
+)
+
+// logfmtTimestampKey is the prefix of every logfmt entry
+var logfmtTimestampKey = []byte("ts=")
+
+// appendLogfmtEntry will append the entry as logfmt key-value pairs to the provided buffer
+// Note: Entries are formatted as ts=<nanoseconds> [level=<level>] msg=<message> followed by the optional metadata
+// and any fields, E.g. ts=1700000000 level=INFO msg="hello world" service=api
+func appendLogfmtEntry(buf []byte, e entry) []byte {
+	buf = append(buf, "ts="...)
+	buf = strconv.AppendInt(buf, time.Now().UnixNano(), 10)
+
+	if e.level != levelNone {
+		buf = appendLogfmtPair(buf, "level", e.level.name())
+	}
+
+	buf = appendLogfmtPair(buf, "msg", string(e.prefix)+string(e.msg))
+
+	if e.hostname != "" {
+		buf = appendLogfmtPair(buf, "hostname", e.hostname)
+	}
+
+	if e.pid != 0 {
+		buf = append(buf, " pid="...)
+		buf = strconv.AppendInt(buf, int64(e.pid), 10)
+	}
+
+	if e.caller != "" {
+		buf = appendLogfmtPair(buf, "caller", e.caller)
+	}
+
+	if len(e.stack) > 0 {
+		buf = appendLogfmtPair(buf, "stack", string(e.stack))
+	}
+
+	for _, f := range e.fields {
+		buf = appendLogfmtPair(buf, f.key, f.value)
+	}
+
+	return buf
+}
+
+// appendLogfmtPair will append a space preceded key-value pair to the provided buffer
+func appendLogfmtPair(buf []byte, key, value string) []byte {
+	buf = append(buf, ' ')
+	buf = append(buf, key...)
+	buf = append(buf, '=')
+	return logfmt.AppendValue(buf, value)
+}
+
+// UseLogfmt will set whether or not entries are written as logfmt key-value pairs
+// Note: Entries are written as one logfmt line per entry, E.g. ts=1700000000 level=INFO msg="hello world"
+func (l *Logger) UseLogfmt(useLogfmt bool) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	if useLogfmt {
+		// Set format to logfmt
+		l.format = formatLogfmt
+	} else if l.format == formatLogfmt {
+		// Logfmt is being disabled, revert to the default format
+		l.format = formatText
+	}
+}
+
+// parseLogfmtEntry will parse a logfmt log line into an Entry
+// Note: Unrecognized keys are stored within Extra
+func parseLogfmtEntry(line []byte) (e Entry, err error) {
+	for len(line) > 0 {
+		var key, value string
+		if key, value, line, err = nextLogfmtPair(line); err != nil {
+			return
+		}
+
+		if err = e.setField(key, value); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// nextLogfmtPair will parse the next key-value pair and return the remaining line
+func nextLogfmtPair(line []byte) (key, value string, rest []byte, err error) {
+	// Skip leading spaces
+	line = bytes.TrimLeft(line, " ")
+
+	eq := bytes.IndexByte(line, '=')
+	if eq < 1 {
+		err = ErrInvalidLogfmt
+		return
+	}
+
+	key = string(line[:eq])
+	line = line[eq+1:]
+
+	if len(line) == 0 || line[0] != '"' {
+		// Value is unquoted, value ends at the next space
+		end := bytes.IndexByte(line, ' ')
+		if end == -1 {
+			end = len(line)
+		}
+
+		value = string(line[:end])
+		rest = line[end:]
+		return
+	}
+
+	buf := make([]byte, 0, len(line))
+	for i := 1; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '"':
+			// End of quoted value
+			value = string(buf)
+			rest = line[i+1:]
+			return
+		case c != '\\':
+			buf = append(buf, c)
+			continue
+		case i+1 == len(line):
+			// Escape is missing its character
+			err = ErrInvalidLogfmt
+			return
+		}
+
+		i++
+		switch line[i] {
+		case 'n':
+			buf = append(buf, '\n')
+		case 'r':
+			buf = append(buf, '\r')
+		case 't':
+			buf = append(buf, '\t')
+		case 'u':
+			if i+4 >= len(line) {
+				err = ErrInvalidLogfmt
+				return
+			}
+
+			var r uint64
+			if r, err = strconv.ParseUint(string(line[i+1:i+5]), 16, 16); err != nil {
+				return
+			}
+
+			buf = append(buf, string(rune(r))...)
+			i += 4
+
+		default:
+			buf = append(buf, line[i])
+		}
+	}
+
+	// Quoted value is missing its closing quote
+	err = ErrInvalidLogfmt
+	return
+}
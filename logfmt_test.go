@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/go-logfmt/logfmt"
+)
+
+func TestUseLogfmt(t *testing.T) {
+	var (
+		l *Logger
+		f *os.File
+
+		entries []map[string]string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.UseLogfmt(true)
+
+	if err = l.AddField("bad key", "value"); err != ErrInvalidFieldKey {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidFieldKey, err)
+	}
+
+	if err = l.AddField("service", "api server"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.AddField("env", "prod"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Info([]byte(`hello "world" a=b \ done`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("plain"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("bad\nmessage"); err != ErrMessageContainsNewline {
+		t.Fatalf("invalid error, expected %v and received %v", ErrMessageContainsNewline, err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if f, err = os.Open(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	d := logfmt.NewDecoder(f)
+	for d.ScanRecord() {
+		entry := make(map[string]string)
+		for d.ScanKeyval() {
+			entry[string(d.Key())] = string(d.Value())
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err = d.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []map[string]string{
+		{"level": "INFO", "msg": `hello "world" a=b \ done`, "env": "prod", "service": "api server"},
+		{"msg": "plain", "env": "prod", "service": "api server"},
+	}
+
+	if len(entries) != len(expected) {
+		t.Fatalf("invalid number of entries, expected %d and received %d", len(expected), len(entries))
+	}
+
+	for i, entry := range entries {
+		if _, err = strconv.ParseInt(entry["ts"], 10, 64); err != nil {
+			t.Fatalf("invalid timestamp \"%s\": %v", entry["ts"], err)
+		}
+
+		delete(entry, "ts")
+		if len(entry) != len(expected[i]) {
+			t.Fatalf("invalid number of keys, expected %d and received %d", len(expected[i]), len(entry))
+		}
+
+		for key, value := range expected[i] {
+			if entry[key] != value {
+				t.Fatalf("invalid %s, expected \"%s\" and received \"%s\"", key, value, entry[key])
+			}
+		}
+	}
+}
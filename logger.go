@@ -3,8 +3,10 @@ package logger
 import (
 	"bufio"
 	"bytes"
+	"crypto/cipher"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"sync"
@@ -17,8 +19,82 @@ import (
 const (
 	// ErrMessageContainsNewline is returned when a message contains a newline
 	ErrMessageContainsNewline = errors.Error("message contains newline, which is not a valid character")
-	// ErrInvalidRotationInterval is returned when a rotation interval is set to zero
-	ErrInvalidRotationInterval = errors.Error("rotation interval cannot be zero")
+	// ErrInvalidName is returned when a logger name is empty
+	ErrInvalidName = errors.Error("name cannot be empty")
+	// ErrMissingSeparator is returned when a log line does not contain a separator
+	ErrMissingSeparator = errors.Error("line does not contain a separator")
+	// ErrInvalidDir is returned when a logger directory is empty
+	ErrInvalidDir = errors.Error("dir cannot be empty")
+	// ErrInvalidLevel is returned when a level name is not a known level
+	ErrInvalidLevel = errors.Error("level must be one of debug, info, warn, error or fatal")
+	// ErrInvalidFormat is returned when a format name is not a known format
+	ErrInvalidFormat = errors.Error("format must be one of text, json, logfmt, csv or msgpack")
+	// ErrInvalidRotationInterval is returned when a rotation interval is less than or equal to zero
+	ErrInvalidRotationInterval = errors.Error("rotation interval must be greater than zero")
+	// ErrInvalidRotateMode is returned when a rotate mode is not a known mode
+	ErrInvalidRotateMode = errors.Error("rotate mode must be RotateModeNewFile or RotateModeTruncate")
+	// ErrInvalidBufferSize is returned when a buffer size is less than or equal to zero
+	ErrInvalidBufferSize = errors.Error("buffer size must be greater than zero")
+	// ErrInvalidDelimiter is returned when a delimiter is empty or contains a newline
+	ErrInvalidDelimiter = errors.Error("delimiter cannot be empty or contain a newline")
+	// ErrInvalidSeparator is returned when a separator is set to a newline
+	ErrInvalidSeparator = errors.Error("separator cannot be a newline")
+	// ErrInvalidPrefix is returned when a prefix contains a newline
+	ErrInvalidPrefix = errors.Error("prefix cannot contain a newline")
+	// ErrInvalidLogfmt is returned when a logfmt line cannot be parsed
+	ErrInvalidLogfmt = errors.Error("invalid logfmt line")
+	// ErrInvalidFieldKey is returned when a field key is empty, reserved (E.g. msg) or contains a space, '=', '"' or a
+	// newline
+	ErrInvalidFieldKey = errors.Error("field key cannot be empty, reserved or contain a space, '=', '\"' or a newline")
+	// ErrInvalidFlushInterval is returned when a flush interval is less than or equal to zero
+	ErrInvalidFlushInterval = errors.Error("flush interval must be greater than zero")
+	// ErrInvalidRateLimitWindow is returned when a rate limit window is less than or equal to zero
+	ErrInvalidRateLimitWindow = errors.Error("rate limit window must be greater than zero")
+	// ErrInvalidSampleRate is returned when a sample rate is less than one
+	ErrInvalidSampleRate = errors.Error("sample rate must be greater than zero")
+	// ErrGELFMessageTooLarge is returned when a GELF message requires more than the maximum number of chunks
+	ErrGELFMessageTooLarge = errors.Error("GELF message exceeds the maximum number of chunks")
+	// ErrArchiveExists is returned when an archive destination already exists and overwrite is not set
+	ErrArchiveExists = errors.Error("archive destination already exists")
+	// ErrNoFilesToArchive is returned when no log files fall within an archive's time range
+	ErrNoFilesToArchive = errors.Error("no log files within the archive time range")
+	// ErrTCPBufferFull is passed to a TCP writer's error handler when an entry is dropped while disconnected
+	ErrTCPBufferFull = errors.Error("tcp writer buffer is full, entry dropped")
+	// ErrInvalidTruncationMarker is returned when a truncation marker contains a newline
+	ErrInvalidTruncationMarker = errors.Error("truncation marker cannot contain a newline")
+	// ErrDiskFull is returned when the free disk space of the log directory is below the minimum
+	ErrDiskFull = errors.Error("free disk space is below the minimum, message not written")
+	// ErrMinFreeDiskUnsupported is passed to the error handler when a minimum free disk space is set on a platform
+	// which cannot determine free disk space
+	ErrMinFreeDiskUnsupported = errors.Error("free disk space checks are not supported on this platform")
+	// ErrInvalidCombinedLogLine is returned when a line cannot be parsed as the Combined Log Format
+	ErrInvalidCombinedLogLine = errors.Error("invalid combined log format line")
+	// ErrWriteTimeout is returned when a write does not complete within the write timeout
+	ErrWriteTimeout = errors.Error("write timed out, logger is degraded")
+	// ErrNoCurrentFile is returned when following a logger which does not have a current file (E.g. discard loggers)
+	ErrNoCurrentFile = errors.Error("logger does not have a current file")
+	// ErrInvalidMessagePack is returned when a length-prefixed entry is not a valid MessagePack map
+	ErrInvalidMessagePack = errors.Error("invalid MessagePack entry")
+	// ErrInvalidHMAC is returned when an entry does not match its HMAC
+	ErrInvalidHMAC = errors.Error("entry does not match its HMAC")
+	// ErrInvalidLevelRange is returned when a route's minimum level is greater than its maximum level
+	ErrInvalidLevelRange = errors.Error("minimum level cannot be greater than maximum level")
+	// ErrInvalidEncryptionKey is returned when an encryption key is not 32 bytes
+	ErrInvalidEncryptionKey = errors.Error("encryption key must be 32 bytes")
+	// ErrDecryptionFailed is returned when an entry cannot be decrypted
+	ErrDecryptionFailed = errors.Error("entry could not be decrypted")
+	// ErrAlreadyRegistered is returned when a logger is registered with a name which is already registered
+	ErrAlreadyRegistered = errors.Error("a logger with this name is already registered")
+	// ErrInvalidLogger is returned when a nil logger is registered
+	ErrInvalidLogger = errors.Error("logger cannot be nil")
+	// ErrInvalidQueueDepth is returned when an async queue depth is less than one
+	ErrInvalidQueueDepth = errors.Error("queue depth must be greater than zero")
+	// ErrIsAsync is returned when async mode is enabled for a logger which is already async
+	ErrIsAsync = errors.Error("logger is already in async mode")
+	// ErrCloseTimeout is returned when a logger does not close within the provided timeout
+	ErrCloseTimeout = errors.Error("timed out waiting for logger to close")
+	// ErrQueueFull is returned when an async logger's queue is full
+	ErrQueueFull = errors.Error("async queue is full")
 
 	// Break will break a ForEach loop early and still yield a nil error
 	Break = errors.Error("break")
@@ -27,49 +103,290 @@ const (
 const (
 	// loggerFlag is the os file flags used for log files
 	loggerFlag = os.O_RDWR | os.O_APPEND | os.O_CREATE
+
+	// defaultSeparator is the default separator between the fields of text entries
+	defaultSeparator = '@'
 )
 
 var (
 	// newline as a byteslice
 	newline = []byte("\n")
+
+	// exit is called by Fatal after the logger has been closed
+	exit = os.Exit
+
+	// bufferPool is used to re-use buffers for formatted messages
+	bufferPool = sync.Pool{
+		New: func() interface{} {
+			return bytes.NewBuffer(nil)
+		},
+	}
 )
 
 // New will return a new instance of Logger
 func New(dir, name string) (lp *Logger, err error) {
-	var l Logger
-	l.dir = dir
-	l.name = name
+	return NewWithOptions(dir, name)
+}
+
+// NewWithOptions will return a new instance of Logger with the provided options applied
+// Note: Options are applied in order before the logger is returned, so no entries can be written prior to the logger
+// being fully configured. If an option returns an error, the logger is closed and the error is returned
+func NewWithOptions(dir, name string, opts ...Option) (lp *Logger, err error) {
+	l := newLogger(dir, name)
+
+	// Ensure the log directory exists
+	if err = l.createDir(); err != nil {
+		return
+	}
 
 	// Set initial logger file
-	if err = l.setFile(); err != nil {
+	if err = l.setFile(rotationReasonNone); err != nil {
 		return
 	}
 
-	// Assign lp as a pointer to our created logger
-	lp = &l
+	// Replay any entries left within the write-ahead log by a crashed process
+	if err = l.replayWAL(); err != nil {
+		l.closeFile()
+		return
+	}
+
+	for _, opt := range opts {
+		if err = opt(l); err != nil {
+			l.Close()
+			return
+		}
+	}
+
+	// Assign lp as our created logger
+	lp = l
 	return
 }
 
+// newLogger will return a new instance of Logger with default settings and no underlying file
+func newLogger(dir, name string) *Logger {
+	var l Logger
+	l.dir = dir
+	l.name = name
+	l.publicName.Store(name)
+	l.sep = defaultSeparator
+	l.stackDelim = defaultStackDelimiter
+	l.truncationMarker.Store(defaultTruncationMarker)
+	l.dirPerm = defaultDirPermissions
+	l.filePerm = defaultFilePermissions
+	return &l
+}
+
 // Logger will manage system logs
 type Logger struct {
 	mu sync.Mutex
 	f  *os.File
 	w  *bufio.Writer
 
+	// Mode used when creating the log directory
+	dirPerm os.FileMode
+	// Whether or not the log directory was created by the logger
+	createdDir bool
+	// Mode used when creating log files
+	filePerm os.FileMode
+
+	// Path of the current file
+	filename string
+	// Temporary path of the current file, set until the file receives its first entry (when atomic swap is enabled)
+	tmpFilename string
+
 	// Log directory
 	dir string
 	// Log name
 	name string
+	// Log name, mirrored so Name can be called without the lock (E.g. by a tee writer)
+	publicName atoms.String
+	// Name applied once the current file has been closed (set by SetName)
+	pendingName string
 
 	// Number of lines before rotation (defaults to unlimited)
 	numLines int
+	// Number of bytes before rotation (defaults to unlimited)
+	maxBytes int64
 	// Duration before rotation (defaults to unlimited)
 	rotateInterval time.Duration
-
-	onRotate RotateFn
+	// Record rotations within the manifest file
+	manifest bool
+	// Time the current file was opened
+	openedAt time.Time
+
+	// Time the rotation loop last checked for rotation
+	lastRotationCheck time.Time
+	// How files are rotated (defaults to RotateModeNewFile)
+	rotateMode RotateMode
+
+	onRotate   RotateFn
+	onRotation RotationHook
+	onError    ErrorFn
+
+	// Functions to be called once the lock is released
+	pending []func()
+
+	// Compress files after they are closed
+	compress bool
+	// Write a checksum sidecar file after files are closed
+	integrity bool
+	// Rename files to include the hash of their contents after they are closed
+	hashInFilename bool
+	// Key used to sign each entry with an HMAC (set when signing is enabled)
+	hmacKey []byte
+	// Cipher used to encrypt each entry (set when encryption is enabled)
+	aead cipher.AEAD
+	// Maintain a symlink which points to the current file
+	latestSymlink bool
+	// Open new files with a temporary extension until their first entry
+	atomicSwap bool
+	// Current file was provided by the caller rather than opened by the logger
+	inherited bool
+	// Current file cannot be synced (E.g. an inherited pipe or socket)
+	unsyncable bool
+
+	// Number of rotated files to retain (defaults to unlimited)
+	retainCount int
+	// Duration to retain rotated files (defaults to unlimited)
+	retainDuration time.Duration
+	// Maximum number of bytes used by all log files (defaults to unlimited)
+	maxDiskUsage int64
+
+	// Minimum level of messages to write (defaults to LevelDebug)
+	level Level
+	// Format of log entries (defaults to formatText)
+	format format
+	// Separator between the fields of text entries (defaults to '@')
+	sep byte
+	// Prefix prepended to every message (defaults to none)
+	prefix []byte
+	// Fields included within structured entries
+	fields map[string]string
+	// Fields sorted by key, rebuilt whenever the fields are modified
+	fieldList []field
+
+	// Protects extractors, which are read without holding the logger lock
+	extractorsMu sync.RWMutex
+	// Context keys whose values are included as fields of entries logged with LogContext
+	extractors []contextExtractor
+
+	// Hooks which transform messages before they are written, called in order
+	hooks []Hook
+
+	// Time layout used for timestamps (defaults to unix nanoseconds)
+	tsFormat string
+	// Function used to produce timestamps, takes precedence over tsFormat
+	tsFunc TimestampFn
+
+	// Hostname to include in entries (defaults to disabled)
+	hostname string
+	// Process ID to include in entries (defaults to disabled)
+	pid int
+
+	// Stack depth of the caller to include in entries (defaults to disabled)
+	callerDepth int
+	// Include the full path of the caller file rather than the base name
+	callerFullPath bool
+
+	// Levels which include a stack trace within their entries
+	captureStack [len(levelBytes)]bool
+	// Delimiter used to separate stack frames
+	stackDelim []byte
 
 	// Current line count
 	count int
+	// Current file size (in bytes)
+	size int64
+
+	// Lifetime statistics, the current file fields are populated when a snapshot is taken
+	stats Stats
+
+	// Entry buffer, reused for each entry
+	buf []byte
+	// Size of the write buffer (defaults to bufio's default size)
+	bufferSize int
+
+	// Window for deduplicating repeated entries (defaults to disabled)
+	dedupWindow time.Duration
+	// State of the previous entry, used for deduplication
+	dedupState dedupState
+
+	// Rate limiter for repeated messages (set when a rate limit is set)
+	rateLimit *rateLimiter
+
+	// Async queue (set when async mode is enabled)
+	queue chan entry
+	// Closed by the async loop once the queue has been drained
+	queueDone chan struct{}
+	// Number of queued entries which have not yet been written
+	queued atoms.Int64
+
+	// Signal listener (set when listening for rotation signals)
+	signals *signalListener
+
+	// Tracks in-flight retention goroutines, Close waits for these to complete
+	retention sync.WaitGroup
+
+	// Closed to stop the flush loop (set when a flush interval is set)
+	flushQuit chan struct{}
+	// Closed to stop the rotation loop (set when a rotation interval is set)
+	rotateQuit chan struct{}
+	// Timer for the next midnight rotation (set when midnight rotation is enabled)
+	midnightTimer *time.Timer
+	// Timer for the next hourly rotation (set when hourly rotation is enabled)
+	hourlyTimer *time.Timer
+	// Base and sequence number of the last date based filename, see getDatedFilename
+	datedBase string
+	datedSeq  int
+
+	// Writer which receives a copy of each entry (defaults to disabled)
+	tee io.Writer
+
+	// Hold an exclusive file lock while writing each entry
+	fileLock bool
+
+	// Write-ahead log (set when the write-ahead log is enabled)
+	wal *os.File
+
+	// Discard all messages without any I/O
+	discard bool
+
+	// Write entries to stderr when the file cannot be written to
+	stderrFallback bool
+	// State of the stderr fallback
+	fallback fallbackState
+
+	// Maximum duration of each write, zero is disabled
+	writeTimeout time.Duration
+	// Closed when a stalled write completes (set when a write has timed out)
+	stalled chan struct{}
+
+	// Escape newlines within messages rather than rejecting them
+	escapeNewlines atoms.Bool
+	// Base64 encode messages rather than rejecting newlines
+	base64Messages atoms.Bool
+
+	// Minimum number of free bytes on the log directory's filesystem, zero is disabled
+	minFreeDisk atoms.Int64
+
+	// Maximum number of message bytes, zero is unlimited
+	maxLineLength atoms.Int64
+	// Suffix appended to truncated messages
+	truncationMarker atoms.String
+
+	// Write 1 in every sampleRate messages, zero and one write every message
+	sampleRate atoms.Uint64
+	// Number of sampled log calls
+	sampleCount atoms.Uint64
+
+	// Ring buffer of the most recent entries (set when a memory buffer is set)
+	memory *memoryBuffer
+
+	// Number of entries written for each level, for the lifetime of the logger
+	levelCounts [len(levelBytes)]atoms.Int64
+
+	// Async mode state, mirrors whether or not the queue is set
+	async atoms.Bool
 
 	// Closed state
 	closed atoms.Bool
@@ -83,26 +400,135 @@ func (l *Logger) isClosed() (ok bool) {
 
 // setFile will set the underlying logger file
 // Note: This will close the currently opened file
-func (l *Logger) setFile() (err error) {
+func (l *Logger) setFile(reason RotationReason) (err error) {
+	if l.discard {
+		// Discard loggers do not have an underlying file, return
+		return
+	}
+
+	// Determine whether or not the current file has entries
+	// Note: Empty files are removed on close, so they are not recorded or passed to the rotation hook
+	hasEntries := l.f != nil && l.count > 0
+
+	var closed ManifestEntry
+	if l.manifest && hasEntries {
+		// Manifest is enabled, create an entry for the file being closed
+		closed = l.newManifestEntry(reason)
+	}
+
+	if l.rotateMode == RotateModeTruncate && l.f != nil {
+		if l.manifest && hasEntries {
+			// Record the file being truncated
+			l.writeManifestEntry(closed)
+		}
+
+		// Truncate mode is set, truncate the current file in place
+		return l.truncateFile()
+	}
+
+	// Determine whether or not this is a rotation (rather than the initial file)
+	rotated := l.f != nil
+
 	// Close existing file (if it exists)
 	if err = l.closeFile(); err != nil {
 		return
 	}
 
+	// Apply the pending name (if set), so the next file is opened with the new name
+	if err = l.applyName(); err != nil {
+		return
+	}
+
+	var oldFilename string
+	if hasEntries {
+		// Get the closed file's name, we need this for the rotation hook
+		// Note: The file may have been renamed on close (E.g. to include its hash)
+		oldFilename = l.filename
+	}
+
+	if l.manifest && hasEntries {
+		// Manifest is enabled, record the file being closed
+		closed.Path = oldFilename
+		l.writeManifestEntry(closed)
+	}
+
 	// Open a file with our directory, name, and current timestamp
-	if l.f, err = os.OpenFile(l.getFilename(), loggerFlag, 0644); err != nil {
+	if err = l.openFile(l.getFilename()); err != nil {
 		return
 	}
 
+	// Set the time the file was opened, used by the manifest
+	l.openedAt = time.Now()
+
 	// Set writer
-	l.w = bufio.NewWriter(l.f)
-	// Reset count to zero
+	l.w = l.newWriter()
+	// Reset count and size to zero
 	l.count = 0
+	l.size = 0
+
+	if rotated {
+		// Update rotation stats
+		l.stats.TotalRotations++
+		l.stats.LastRotationTime = time.Now()
+	}
+
+	if l.retainCount > 0 || l.retainDuration > 0 || l.maxDiskUsage > 0 {
+		// Retention policy is set, apply retention within a goroutine
+		r := newRetention(l)
+		l.retention.Add(1)
+		go func() {
+			defer l.retention.Done()
+			r.apply()
+		}()
+	}
+
+	if l.latestSymlink && l.tmpFilename == "" {
+		// Latest symlink is enabled, point it to the new file
+		// Note: Temporary files are pointed to once they have been renamed
+		if err = l.updateLatest(); err != nil {
+			return
+		}
+	}
+
+	if oldFilename != "" && l.onRotation != nil {
+		// File has been rotated & rotation hook is set, call hook once the lock is released
+		l.addPending(newRotationHookCall(l.onRotation, oldFilename, l.filename))
+	}
+
 	return
 }
 
+// addPending will add a function to be called once the lock is released
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) addPending(fn func()) {
+	l.pending = append(l.pending, fn)
+}
+
+// unlock will release the lock and call any pending functions
+func (l *Logger) unlock() {
+	// Take ownership of pending functions while the lock is still held
+	pending := l.pending
+	l.pending = nil
+	// Release lock
+	l.mu.Unlock()
+
+	for _, fn := range pending {
+		fn()
+	}
+}
+
+// newWriter will return a new buffered writer for the current file using the configured buffer size
+func (l *Logger) newWriter() *bufio.Writer {
+	if l.bufferSize > 0 {
+		return bufio.NewWriterSize(l.f, l.bufferSize)
+	}
+
+	return bufio.NewWriter(l.f)
+}
+
 // closeFile will close the underlying logger file
-// Note: This will flush the buffer and file before closing
+// Note: This will flush the buffer and file before closing. The file is closed even if the flush fails, in which case
+// an *errors.ErrorList containing both errors is returned when the close also fails
 func (l *Logger) closeFile() (err error) {
 	if l.f == nil {
 		// File does not exist - no need to close, return
@@ -110,15 +536,24 @@ func (l *Logger) closeFile() (err error) {
 	}
 
 	// Get current file's name, we need this for post-close actions
-	name := l.f.Name()
+	name := l.filename
+	if l.tmpFilename != "" {
+		// File has not been renamed, use the temporary filename
+		name = l.tmpFilename
+	}
 
+	var errs errors.ErrorList
 	// Flush contents
-	if err = l.flush(); err != nil {
-		return
-	}
+	// Note: The file is closed even if the flush fails, so the file descriptor is not leaked
+	errs.Push(l.flush())
+
+	// Determine whether or not the file is empty, we need this for post-close actions
+	// Note: A re-opened file (E.g. a date-named file) may have contents while our count is zero
+	empty := l.count == 0 && isEmptyFile(l.f)
 
 	// Close file
-	if err = l.f.Close(); err != nil {
+	errs.Push(l.f.Close())
+	if err = errs.Err(); err != nil {
 		return
 	}
 
@@ -126,10 +561,35 @@ func (l *Logger) closeFile() (err error) {
 	l.f = nil
 	// Set buffer to nil
 	l.w = nil
+	// Clear temporary filename
+	l.tmpFilename = ""
+
+	if l.inherited {
+		// File was provided by the caller (see NewFromExistingFile), it is never removed, renamed or processed
+		l.inherited = false
+		l.unsyncable = false
+		l.count = 0
+		return
+	}
+
+	if l.count > 0 && l.hashInFilename {
+		// Rename file to include the hash of its contents
+		if hashed, herr := renameWithHash(name); herr != nil {
+			l.addPending(newErrorCall(l.errorHandler(), fmt.Errorf("error renaming file with hash: %v", herr)))
+		} else {
+			l.filename = hashed
+			name = hashed
+		}
+	}
 
 	if l.count == 0 {
-		// File has no contents, remove file within a gorotuine
-		go os.Remove(name)
+		if empty {
+			// File has no contents, remove file
+			os.Remove(name)
+		}
+	} else if l.compress || l.integrity {
+		// File has been rotated & compression or integrity checks are enabled, process file within a goroutine
+		go processRotated(name, l.compress, l.integrity, l.onRotate, l.errorHandler())
 	} else if l.onRotate != nil {
 		// File has been rotated & onRotate func is set, call on on rotate func within a gorotuine
 		go l.onRotate(name)
@@ -140,24 +600,53 @@ func (l *Logger) closeFile() (err error) {
 }
 
 // flush will flush the contents of the buffer and sync the underlying file
+// Note: The file is synced even if the buffer flush fails, errors from both steps are returned as an *errors.ErrorList
 func (l *Logger) flush() (err error) {
+	if l.w == nil {
+		// Writer does not exist (E.g. discard logger), return
+		return
+	}
+
+	if l.fallback.failed {
+		// File is unavailable and entries are being written to stderr, return
+		return
+	}
+
+	// Wait for a stalled write (if any), as it owns the write buffer until it completes
+	l.waitStalled()
+
+	var errs errors.ErrorList
 	// Flush buffer
-	if err = l.w.Flush(); err != nil {
+	errs.Push(l.w.Flush())
+	// Flush file, this is attempted even if the buffer flush failed so any partially flushed entries are persisted
+	if !l.unsyncable {
+		errs.Push(l.f.Sync())
+	}
+	if err = errs.Err(); err != nil {
+		// Entries may not be persisted, return without truncating the write-ahead log
 		return
 	}
 
-	// Flush file
-	return l.f.Sync()
+	// Buffered entries are now persisted, truncate the write-ahead log (if enabled)
+	return l.truncateWAL()
 }
 
-// rotationLoop will manage a rotation loop to call rotate on a set interval
-func (l *Logger) rotationLoop() {
+// rotationLoop will manage a rotation loop to call rotate on a set interval until the quit channel is closed or the
+// logger is closed
+func (l *Logger) rotationLoop(interval time.Duration, quit <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
 	var err error
 	for {
-		// Sleep for rotation interval
-		time.Sleep(l.rotateInterval)
+		select {
+		case <-ticker.C:
+		case <-quit:
+			return
+		}
+
 		// Attempt to rotate underlying log file
-		err = l.rotate()
+		err = l.rotate(quit)
 
 		switch err {
 		case nil:
@@ -167,17 +656,49 @@ func (l *Logger) rotationLoop() {
 			return
 
 		default:
-			// We encountered an unexpected error, print to stdout
-			fmt.Printf("logger :: %s :: error rotating file: %v", l.name, err)
+			// We encountered an unexpected error, pass to the error handler
+			l.handleError(fmt.Errorf("error rotating file: %v", err))
 		}
 	}
 }
 
-func (l *Logger) rotate() (err error) {
+// stopRotationLoop will stop the rotation loop (if set)
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) stopRotationLoop() {
+	if l.rotateQuit == nil {
+		return
+	}
+
+	close(l.rotateQuit)
+	l.rotateQuit = nil
+}
+
+// handleError will pass an error to the error handler
+// Note: The lock must not be held by the caller, as the error handler may call the logger
+func (l *Logger) handleError(err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Get error handler while the lock is held
+	onError := l.errorHandler()
+	// Release lock
+	l.mu.Unlock()
+	// Call error handler with the lock released
+	onError(err)
+}
+
+// HandleError will pass an error to the logger's error handler
+// Note: This is intended for errors which occur outside of a caller's request (E.g. within an integration's background
+// goroutine), it must not be called while the logger is writing (E.g. from a tee writer)
+func (l *Logger) HandleError(err error) {
+	l.handleError(err)
+}
+
+// rotate will rotate the current file for the rotation loop owning the provided quit channel
+func (l *Logger) rotate(quit <-chan struct{}) (err error) {
 	// Acquire lock
 	l.mu.Lock()
 	// Defer the release of our lock
-	defer l.mu.Unlock()
+	defer l.unlock()
 
 	// Ensure the logger has not been closed
 	if l.isClosed() {
@@ -185,95 +706,457 @@ func (l *Logger) rotate() (err error) {
 		return errors.ErrIsClosed
 	}
 
+	select {
+	case <-quit:
+		// Rotation loop was stopped while waiting for the lock, return
+		return
+	default:
+	}
+
+	// Set the time of the interval rotation check, used to estimate the next rotation
+	l.lastRotationCheck = time.Now()
+
 	// Don't set new file if count is zero
 	if l.count == 0 {
 		return
 	}
 
 	// Set a new underlying log file
-	return l.setFile()
+	return l.setFile(RotationReasonTime)
+}
+
+// errorHandler will return the error handler, falling back to printing to stdout when unset
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) errorHandler() (fn ErrorFn) {
+	if fn = l.onError; fn != nil {
+		return
+	}
+
+	name := l.name
+	return func(err error) {
+		fmt.Printf("logger :: %s :: %v\n", name, err)
+	}
 }
 
 // getFilename will get the current full filename for the log
 // Note: This function is time-sensitive (seconds)
 func (l *Logger) getFilename() (filename string) {
-	// Get current unix timestamp
-	now := time.Now().UnixNano()
+	// Get current timestamp
+	now := timeNow()
+	if l.hourlyTimer != nil {
+		// Hourly rotation is enabled, create a filename using the current date and hour
+		return l.getDatedFilename(now.Format(hourLayout))
+	}
+
+	if l.midnightTimer != nil {
+		// Midnight rotation is enabled, create a filename using the current date
+		return l.getDatedFilename(now.Format(dateLayout))
+	}
+
 	// Create a filename by:
 	//	- Concatinate directory and name
 	//	- Append unix timestamp
 	//	- Append log extension
-	return fmt.Sprintf("%s.%d.log", path.Join(l.dir, l.name), now)
+	return fmt.Sprintf("%s.%d.log", path.Join(l.dir, l.name), now.UnixNano())
 }
 
-// logMessage will log the full message (prefix, message, suffix)
-func (l *Logger) logMessage(msg []byte) (err error) {
-	// Write timestamp
-	if _, err = l.w.Write(getTimestampBytes()); err != nil {
+// formatEntry will format the full message into the entry buffer using the configured format
+// Note: The entry is updated with the transformed message and the logger metadata
+func (l *Logger) formatEntry(e *entry) (err error) {
+	// Transform message with hooks (if set)
+	if e.msg, err = l.applyHooks(e.msg); err != nil {
 		return
 	}
 
-	// Write '@', which separates timestamp and the message
-	if err = l.w.WriteByte('@'); err != nil {
+	// Reset entry buffer
+	l.buf = l.buf[:0]
+	// Set process metadata (if enabled)
+	e.hostname = l.hostname
+	e.pid = l.pid
+	// Set message prefix (if set)
+	e.prefix = l.prefix
+	// Set fields (if set)
+	e.fields = l.fieldList
+	if len(e.ctxFields) > 0 {
+		// Include fields extracted from the context
+		e.fields = mergeFields(e.fields, e.ctxFields)
+	}
+
+	out := *e
+	if l.base64Messages.Get() || hasBase64Marker(out.prefix, out.msg) {
+		// Base64 encoding is enabled or the message resembles an encoded message, encode the prefix and message
+		out.msg = encodeBase64Message(out.prefix, out.msg)
+		out.prefix = nil
+	}
+
+	// Start of the entry line, following the CSV header row (if any)
+	var start int
+	switch {
+	case l.format == formatMessagePack:
+		// MessagePack entries are length-prefixed rather than followed by a newline
+		if l.buf, err = appendMessagePackEntry(l.buf, out); err != nil {
+			return
+		}
+
+		if l.hmacKey != nil {
+			// Sign entry
+			l.buf = appendLengthPrefixedHMAC(l.buf, l.hmacKey, l.sep)
+		}
+
+		if l.aead != nil {
+			// Encrypt entry
+			l.buf, err = encryptLengthPrefixed(l.aead, l.buf)
+		}
+
 		return
+	case l.format == formatCompact:
+		l.buf = append(l.buf, out.prefix...)
+		l.buf = append(l.buf, out.msg...)
+	case l.format == formatJSON || out.attrs != nil:
+		l.buf, err = appendJSONEntry(l.buf, out)
+	case l.format == formatLogfmt:
+		l.buf = appendLogfmtEntry(l.buf, out)
+	case l.format == formatCSV:
+		if l.needsCSVHeader() {
+			// File is new, precede the entry with the header row
+			if l.buf, err = appendCSVHeader(l.buf, out.fields); err != nil {
+				return
+			}
+
+			if l.hmacKey != nil {
+				// Sign header row
+				l.buf = appendHMAC(l.buf, 0, l.hmacKey, l.sep)
+			}
+
+			l.buf = append(l.buf, '\n')
+			start = len(l.buf)
+		}
+
+		l.buf, err = appendCSVEntry(l.buf, out)
+	default:
+		l.buf = l.appendTextEntry(l.buf, out)
 	}
 
-	// Write message
-	if _, err = l.w.Write(msg); err != nil {
+	if err != nil {
 		return
 	}
 
-	// Write newline to follow message
-	return l.w.WriteByte('\n')
+	if l.hmacKey != nil {
+		// Sign entry
+		l.buf = appendHMAC(l.buf, start, l.hmacKey, l.sep)
+	}
+
+	if l.aead != nil {
+		// Encrypt entry
+		if l.buf, err = encryptLines(l.aead, l.buf); err != nil {
+			return
+		}
+	}
+
+	// Append newline to follow entry
+	l.buf = append(l.buf, '\n')
+	return
+}
+
+// writeBuffer will write the formatted entry buffer to the current file
+func (l *Logger) writeBuffer() (err error) {
+	// Wait for a stalled write (if any), as it owns the writer until it completes
+	if err = l.awaitStalled(); err != nil {
+		return
+	}
+
+	if l.wal != nil {
+		// Write entry to the write-ahead log before buffering
+		if err = l.writeWAL(l.buf); err != nil {
+			return
+		}
+	}
+
+	var n int
+	if l.fileLock {
+		// Flush any buffered entries so entries remain in order
+		err = l.flush()
+	}
+
+	// Note: The write may continue in the background after a timeout, so it only references copies of our state
+	buf, f, w, fileLock := l.buf, l.f, l.w, l.fileLock
+	if err == nil {
+		n, err = l.timedWrite(func() (int, error) {
+			if fileLock {
+				// Write entry directly to the file while holding the file lock
+				return writeLocked(f, buf)
+			}
+
+			// Write entry
+			return w.Write(buf)
+		})
+	}
+
+	if err == ErrWriteTimeout {
+		// The stalled write still references the entry buffer, a new buffer will be allocated for the next entry
+		l.buf = nil
+	}
+
+	// Increment current file size and total bytes by the number of bytes written
+	l.size += int64(n)
+	l.stats.TotalBytesWritten += int64(n)
+
+	if err == nil {
+		// Rename the current file from its temporary name (if set), as it now has an entry
+		err = l.renameTemp()
+	}
+
+	// Mirror entry to the tee writer (if set)
+	l.writeTee(buf)
+
+	if err == nil && l.wal != nil && l.w.Buffered() == 0 {
+		// Entry was written directly to the file (E.g. file lock or oversized entry), flush to truncate the write-ahead log
+		err = l.flush()
+	}
+
+	return
 }
 
 // incrementCount will increment the current line count
-// Note: If the line count exceeds the line limit, a new file will be set
+// Note: If the line count or file size exceeds their limits, a new file will be set
 func (l *Logger) incrementCount() (err error) {
-	// Increment count, then ensure new count does not equal our number of lines limit
-	if l.count++; l.numLines == 0 || l.count < l.numLines {
-		// Line number limit unset OR count is less than our lines, return
+	// Increment count, then ensure the file has not reached any of our limits
+	l.count++
+	reason, full := l.isFull()
+	if !full {
+		// File has not reached a limit, return
 		return
 	}
 
-	// Count equals our number of lines limit, set file
-	return l.setFile()
+	// File has reached a limit, set file
+	return l.setFile(reason)
 }
 
-// Log will log a message
-func (l *Logger) Log(msg []byte) (err error) {
+// isFull will return whether or not the current file has reached the line or byte limits, and which limit was reached
+func (l *Logger) isFull() (reason RotationReason, full bool) {
+	switch {
+	case l.numLines > 0 && l.count >= l.numLines:
+		// Line number limit is set and count has reached it
+		return RotationReasonLines, true
+	case l.maxBytes > 0 && l.size >= l.maxBytes:
+		// Byte limit is set and size has reached it
+		return RotationReasonSize, true
+
+	default:
+		return
+	}
+}
+
+// log will log a message with the provided level
+func (l *Logger) log(level Level, msg []byte) (err error) {
+	// Truncate message to the max line length (if set)
+	msg = l.truncateMessage(msg)
 	// Ensure the message is valid before acquiring lock
-	if bytes.Index(msg, newline) > -1 {
-		// Log message contains a newline, return
-		return ErrMessageContainsNewline
+	if err = l.validateMessage(msg); err != nil {
+		return
+	}
+
+	// Ensure there is sufficient free disk space (best-effort, this is checked again before writing)
+	if err = l.checkDiskFree(); err != nil {
+		return
 	}
 
 	// Acquire lock
 	l.mu.Lock()
 	// Defer the release of our lock
-	defer l.mu.Unlock()
+	defer l.unlock()
+
+	// Write message
+	return l.write(newEntry(level, msg))
+}
 
+// logAttrs will log a message with the provided level and pre-encoded JSON attributes
+func (l *Logger) logAttrs(level Level, msg, attrs []byte) (err error) {
+	// Truncate message to the max line length (if set)
+	msg = l.truncateMessage(msg)
+	// Ensure the message is valid before acquiring lock
+	if err = l.validateMessage(msg); err != nil {
+		return
+	}
+
+	// Ensure there is sufficient free disk space (best-effort, this is checked again before writing)
+	if err = l.checkDiskFree(); err != nil {
+		return
+	}
+
+	e := newEntry(level, msg)
+	e.attrs = attrs
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.unlock()
+
+	// Write message
+	return l.write(e)
+}
+
+// write will write an entry
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) write(e entry) (err error) {
 	// Ensure the logger has not been closed
 	if l.isClosed() {
 		// Instance of logger has been closed, return
 		return errors.ErrIsClosed
 	}
 
-	// Log message
-	if err = l.logMessage(msg); err != nil {
+	if !l.sample(e.level) {
+		// Message was sampled out, drop message
+		return
+	}
+
+	// Ensure there is sufficient free disk space now that the lock is held
+	if err = l.checkDiskFree(); err != nil {
+		return
+	}
+
+	if l.rateLimit != nil && !l.rateLimit.allow(e.msg) {
+		// Message has exceeded the rate limit, drop message
 		return
 	}
 
+	if l.callerDepth > 0 {
+		// Caller depth is set, get caller while we are still within the caller's stack
+		e.caller = getCaller(l.callerDepth, l.callerFullPath)
+	}
+
+	if l.shouldCaptureStack(e.level) {
+		// Stack capture is enabled for this level, capture stack while we are still within the caller's stack
+		e.stackDelim = l.stackDelim
+		e.stack = captureStack(l.stackDelim)
+	}
+
+	if l.queue != nil {
+		// Async mode is enabled, enqueue entry to be written by the async loop
+		return l.enqueue(e)
+	}
+
+	// Write entry
+	return l.writeEntry(e)
+}
+
+// writeEntry will write an entry to the underlying file
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) writeEntry(e entry) (err error) {
+	// Ensure the entry level meets our minimum level
+	if e.level != levelNone && e.level < l.level {
+		// Level is below our minimum level, return
+		return
+	}
+
+	if l.discard {
+		// Discard loggers drop all messages, return
+		return
+	}
+
+	if l.dedupWindow > 0 {
+		var skip bool
+		// Deduplication is enabled, determine whether or not this entry repeats the previous entry
+		if skip, err = l.dedup(e); err != nil || skip {
+			return
+		}
+	}
+
+	return l.commitEntry(e)
+}
+
+// commitEntry will log an entry and update the counts
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) commitEntry(e entry) (err error) {
+	// Format message
+	if err = l.formatEntry(&e); err != nil {
+		return
+	}
+
+	if l.memory != nil {
+		// Memory buffer is enabled, retain entry
+		l.memory.push(newMemoryEntry(e))
+	}
+
+	var fellBack bool
+	// Write message, falling back to stderr if the file is unavailable (when enabled)
+	if fellBack, err = l.writeBufferWithFallback(); err != nil || fellBack {
+		// Entries written to stderr are not counted towards the current file
+		return
+	}
+
+	// Increment total lines written
+	l.stats.TotalLinesWritten++
+	// Increment message size count
+	l.stats.MessageSizeHistogram[messageSizeBucket(len(e.msg))]++
+	// Increment level count
+	l.incrementLevelCount(e.level)
 	// Increment line count
 	return l.incrementCount()
 }
 
+// Log will log a message
+func (l *Logger) Log(msg []byte) (err error) {
+	// Log message without a level
+	return l.log(levelNone, msg)
+}
+
+// Debug will log a message with a level of LevelDebug
+func (l *Logger) Debug(msg []byte) (err error) {
+	return l.log(LevelDebug, msg)
+}
+
+// Info will log a message with a level of LevelInfo
+func (l *Logger) Info(msg []byte) (err error) {
+	return l.log(LevelInfo, msg)
+}
+
+// Warn will log a message with a level of LevelWarn
+func (l *Logger) Warn(msg []byte) (err error) {
+	return l.log(LevelWarn, msg)
+}
+
+// Error will log a message with a level of LevelError
+func (l *Logger) Error(msg []byte) (err error) {
+	return l.log(LevelError, msg)
+}
+
+// Fatal will log a message with a level of LevelFatal, close the logger, and exit with a status of 1
+func (l *Logger) Fatal(msg []byte) (err error) {
+	// Log message, we still exit on error as Fatal must never return to the caller
+	err = l.log(LevelFatal, msg)
+	// Close the logger, which will flush the buffer and file before we exit
+	l.Close()
+	// Exit with a status of 1
+	exit(1)
+	return
+}
+
 // LogString will log a string message
 func (l *Logger) LogString(msg string) (err error) {
 	// Convert message to bytes and pass to l.Log
 	return l.Log([]byte(msg))
 }
 
+// Logf will log a formatted message
+func (l *Logger) Logf(format string, args ...interface{}) (err error) {
+	// Acquire a buffer from the pool
+	buf := bufferPool.Get().(*bytes.Buffer)
+	// Defer the release of the buffer back to the pool
+	defer releaseBuffer(buf)
+
+	// Format message into buffer
+	fmt.Fprintf(buf, format, args...)
+	// Pass formatted message to l.Log
+	return l.Log(buf.Bytes())
+}
+
+// LogStringf will log a formatted message
+// Note: This is an alias of Logf
+func (l *Logger) LogStringf(format string, args ...interface{}) (err error) {
+	return l.Logf(format, args...)
+}
+
 // LogJSON will log a generic value as a JSON message
 func (l *Logger) LogJSON(value interface{}) (err error) {
 	var msg []byte
@@ -285,6 +1168,23 @@ func (l *Logger) LogJSON(value interface{}) (err error) {
 	return l.Log(msg)
 }
 
+// Write will log the provided bytes, allowing Logger to be used as an io.Writer
+// Note: A trailing newline is stripped and any remaining newlines will split p into multiple log messages
+func (l *Logger) Write(p []byte) (n int, err error) {
+	// Strip trailing newline
+	msg := bytes.TrimSuffix(p, newline)
+	for _, line := range bytes.Split(msg, newline) {
+		// Log each line as it's own message
+		if err = l.Log(line); err != nil {
+			return
+		}
+	}
+
+	// Return the original length of p to satisfy the io.Writer contract
+	n = len(p)
+	return
+}
+
 // Flush will manually flush the buffer bytes to disk
 // Note: This is not typically needed, only needed in rare and/or debugging situations
 func (l *Logger) Flush() (err error) {
@@ -303,21 +1203,157 @@ func (l *Logger) Flush() (err error) {
 	return l.flush()
 }
 
+// Rotate will close the current log file and open a new one
+// Note: Unlike automatic rotations, this will rotate even when the current file is empty
+func (l *Logger) Rotate() (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	// Set a new underlying log file
+	return l.setFile(RotationReasonManual)
+}
+
 // SetNumLines will set the maximum number of lines per log file
+// Note: If the current file has already reached the new limit, it is rotated immediately. Rotation errors are passed to
+// the error handler. A limit of zero is unlimited
 func (l *Logger) SetNumLines(n int) {
 	// Acquire lock
 	l.mu.Lock()
 	// Defer the release of our lock
-	defer l.mu.Unlock()
+	defer l.unlock()
 	// Set line number limit
 	l.numLines = n
+
+	if n <= 0 || l.count < n || l.isClosed() {
+		// Current file is within the limit (or the logger has been closed), return
+		return
+	}
+
+	// Current file exceeds the new limit, rotate within the same lock acquisition
+	if err := l.setFile(RotationReasonLines); err != nil {
+		l.addPending(newErrorCall(l.errorHandler(), fmt.Errorf("error rotating file: %v", err)))
+	}
+}
+
+// SetMaxBytes will set the maximum number of bytes per log file
+// Note: The entry which reaches the limit is still written in full, rotation occurs immediately after
+func (l *Logger) SetMaxBytes(n int64) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set byte limit
+	l.maxBytes = n
+}
+
+// SetBufferSize will set the size (in bytes) of the write buffer
+// Note: This takes effect on the current file and persists across rotations
+func (l *Logger) SetBufferSize(bytes int) (err error) {
+	// Ensure buffer size is valid
+	if bytes <= 0 {
+		return ErrInvalidBufferSize
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	// Flush the existing buffer before it is replaced
+	if err = l.flush(); err != nil {
+		return
+	}
+
+	if l.bufferSize = bytes; l.f != nil {
+		// Replace writer with one of the new size
+		l.w = l.newWriter()
+	}
+
+	return
+}
+
+// SetSeparator will set the separator between the fields of text entries (defaults to '@')
+// Note: Messages containing the separator are still valid, only newlines are rejected
+func (l *Logger) SetSeparator(sep byte) (err error) {
+	// Ensure separator is valid
+	if sep == '\n' {
+		return ErrInvalidSeparator
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set separator
+	l.sep = sep
+	return
+}
+
+// SetPrefix will set a prefix which is prepended to every message, replacing any existing prefix
+// Note: The prefix is written directly before the message, E.g. timestamp@[level@]prefixmessage. A nil or empty
+// prefix clears the current prefix
+func (l *Logger) SetPrefix(prefix []byte) (err error) {
+	// Ensure prefix is valid
+	if bytes.IndexByte(prefix, '\n') > -1 {
+		return ErrInvalidPrefix
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set prefix, a copy is stored so the caller may reuse the provided slice
+	l.prefix = append([]byte(nil), prefix...)
+	return
+}
+
+// SetLevel will set the minimum level of messages to log
+// Note: Messages logged without a level (E.g. Log, LogString) are never filtered
+func (l *Logger) SetLevel(level Level) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set minimum level
+	l.level = level
+}
+
+// UseJSON will set whether or not entries are written as JSON objects
+// Note: Entries are written as one JSON object per line, E.g. {"ts":1700000000,"level":"INFO","msg":"hello"}
+func (l *Logger) UseJSON(useJSON bool) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	if useJSON {
+		// Set format to JSON
+		l.format = formatJSON
+	} else if l.format == formatJSON {
+		// JSON is being disabled, revert to the default format
+		l.format = formatText
+	}
 }
 
 // SetRotateInterval will set the rotation interval timing of a log file
+// Note: Calling this when a rotation interval is already set will replace the existing interval
 func (l *Logger) SetRotateInterval(duration time.Duration) (err error) {
-	var wasUnset bool
-	// Ensure duration isn't set to zero
-	if duration == 0 {
+	// Ensure duration isn't set to zero (or below)
+	if duration <= 0 {
 		// We do not like rotation intervals of zero, return
 		err = ErrInvalidRotationInterval
 		return
@@ -334,16 +1370,33 @@ func (l *Logger) SetRotateInterval(duration time.Duration) (err error) {
 		return errors.ErrIsClosed
 	}
 
-	// Set unset to true if rotate interval is currently zero
-	wasUnset = l.rotateInterval == 0
 	// Set rotate interval to the provided duration
 	l.rotateInterval = duration
+	// Stop existing rotation loop (if set)
+	l.stopRotationLoop()
+	// Start a new rotation loop with the provided interval
+	l.lastRotationCheck = time.Now()
+	l.rotateQuit = make(chan struct{})
+	go l.rotationLoop(duration, l.rotateQuit)
+	return
+}
 
-	if wasUnset {
-		// Rotate interval was previously unset, initialize rotation loop
-		go l.rotationLoop()
+// ClearRotateInterval will stop interval rotations and unset the rotation interval
+func (l *Logger) ClearRotateInterval() (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
 	}
 
+	// Stop rotation loop (if set)
+	l.stopRotationLoop()
+	l.rotateInterval = 0
 	return
 }
 
@@ -357,17 +1410,149 @@ func (l *Logger) SetRotateFn(fn RotateFn) {
 	l.onRotate = fn
 }
 
+// SetRotationHook will set the function to be called after each rotation
+// Note: The hook is called synchronously once the lock has been released, a nil fn will clear the hook
+func (l *Logger) SetRotationHook(fn RotationHook) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set onRotation value as provided fn
+	l.onRotation = fn
+}
+
+// SetCompressOnRotate will set whether or not log files are gzip compressed after being rotated
+// Note: Compressed files replace the originals, E.g. name.timestamp.log becomes name.timestamp.log.gz
+func (l *Logger) SetCompressOnRotate(compress bool) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set compress value as provided value
+	l.compress = compress
+}
+
+// SetRetainCount will set the maximum number of rotated files to retain
+// Note: The oldest files are removed after each rotation, the currently opened file is not counted
+func (l *Logger) SetRetainCount(n int) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set retain count
+	l.retainCount = n
+}
+
+// SetRetainDuration will set the maximum age of rotated files to retain
+// Note: Files older than the duration are removed after each rotation
+func (l *Logger) SetRetainDuration(duration time.Duration) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set retain duration
+	l.retainDuration = duration
+}
+
+// SetMaxDiskUsage will set the maximum number of bytes used by the logger's files
+// Note: The oldest files are removed after each rotation (within a goroutine) until the total size of all files,
+// including the currently opened file, is within the limit. Removal errors are passed to the error handler
+func (l *Logger) SetMaxDiskUsage(bytes int64) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set max disk usage
+	l.maxDiskUsage = bytes
+}
+
+// SetErrorHandler will set the function to be called when a background error occurs (E.g. failed rotation, failed
+// flush, or failed compression)
+// Note: The handler is never called while the lock is held, so it is safe for the handler to call the logger. A nil fn
+// will reset to the default handler, which prints errors to stdout
+func (l *Logger) SetErrorHandler(fn ErrorFn) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set onError value as provided fn
+	l.onError = fn
+}
+
 // Close will attempt to close an instance of logger
 func (l *Logger) Close() (err error) {
 	if !l.closed.Set(true) {
 		return errors.ErrIsClosed
 	}
 
+	return l.close()
+}
+
+// CloseWithTimeout will attempt to close an instance of logger, waiting at most the provided duration
+// Note: The logger is marked as closed immediately, so no new entries are accepted. If the timeout is exceeded,
+// ErrCloseTimeout is returned and closing (E.g. flushing to a stuck disk) continues in the background. A duration of
+// zero waits indefinitely, the same as Close
+func (l *Logger) CloseWithTimeout(d time.Duration) (err error) {
+	if d == 0 {
+		return l.Close()
+	}
+
+	if !l.closed.Set(true) {
+		return errors.ErrIsClosed
+	}
+
+	// Buffered so the close goroutine does not block once we have stopped waiting
+	errC := make(chan error, 1)
+	go func() {
+		errC <- l.close()
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case err = <-errC:
+		return
+	case <-timer.C:
+		return ErrCloseTimeout
+	}
+}
+
+// close will release the resources of a logger which has been marked as closed
+func (l *Logger) close() (err error) {
+	// Stop listening for rotation signals (if set)
+	l.StopSignalListener()
+	// Drain the async queue (if set)
+	l.closeQueue()
+	// Defer waiting for in-flight retention to complete, this occurs after the lock has been released
+	defer l.retention.Wait()
+
 	// Acquire lock to ensure all writers have completed
 	l.mu.Lock()
-	// Defer the release of our lock
-	defer l.mu.Unlock()
+	// Defer the release of our lock, calling any pending functions (E.g. error handlers from closing the file)
+	defer l.unlock()
+
+	// Stop the flush loop (if set)
+	l.stopFlushLoop()
+	// Stop the rotation loop (if set)
+	l.stopRotationLoop()
+	// Stop the midnight rotation timer (if set)
+	l.stopMidnightTimer()
+	// Stop the hourly rotation timer (if set)
+	l.stopHourlyTimer()
+	// Write the pending deduplication summary (if set)
+	l.stopDedup()
+
+	if l.latestSymlink {
+		// Remove the latest symlink, as there is no longer a current file
+		l.removeLatest()
+	}
 
 	// Close underlying logger file
-	return l.closeFile()
+	if err = l.closeFile(); err != nil {
+		return
+	}
+
+	// Close and remove the write-ahead log (if enabled), all entries have been flushed
+	return l.closeWAL()
 }
@@ -1,10 +1,17 @@
 package logger
 
 import (
+	"bufio"
 	"fmt"
+	"log"
 	"os"
+	"path"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/hatchify/errors"
 )
 
 const (
@@ -12,6 +19,16 @@ const (
 	testName = "testing"
 )
 
+// errTestFlush is returned by errWriter
+var errTestFlush = errors.Error("test flush error")
+
+// errWriter is a writer which always fails
+type errWriter struct{}
+
+func (errWriter) Write(bs []byte) (n int, err error) {
+	return 0, errTestFlush
+}
+
 func TestLogger(t *testing.T) {
 	var (
 		l   *Logger
@@ -102,6 +119,94 @@ func TestTimeRotation(t *testing.T) {
 	}
 }
 
+func TestClearRotateInterval(t *testing.T) {
+	var (
+		l *Logger
+		n int
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetRotateInterval(time.Millisecond * 50); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err = testRotatedFiles(l, time.Millisecond*300); err != nil {
+		t.Fatal(err)
+	}
+
+	if n < 3 {
+		t.Fatalf("invalid number of files, expected at least %d and received %d", 3, n)
+	}
+
+	// Replace the interval, the previous rotation loop must stop
+	if err = l.SetRotateInterval(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err = testRotatedFiles(l, time.Millisecond*300); err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 0 {
+		t.Fatalf("invalid number of files, expected %d and received %d", 0, n)
+	}
+
+	if err = l.SetRotateInterval(time.Millisecond * 50); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.ClearRotateInterval(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err = testRotatedFiles(l, time.Millisecond*300); err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 0 {
+		t.Fatalf("invalid number of files, expected %d and received %d", 0, n)
+	}
+
+	if l.rotateInterval != 0 {
+		t.Fatalf("invalid rotate interval, expected %v and received %v", time.Duration(0), l.rotateInterval)
+	}
+}
+
+// testRotatedFiles will log entries for the provided duration and return the number of files created
+func testRotatedFiles(l *Logger, d time.Duration) (n int, err error) {
+	var before, after []string
+	pattern := path.Join(testDir, testName+".*.log")
+	if before, err = filepath.Glob(pattern); err != nil {
+		return
+	}
+
+	for end := time.Now().Add(d); time.Now().Before(end); {
+		if err = l.LogString("#1"); err != nil {
+			return
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	if after, err = filepath.Glob(pattern); err != nil {
+		return
+	}
+
+	n = len(after) - len(before)
+	return
+}
+
 func testLogs(l *Logger, n int) (err error) {
 	for i := 0; i < n; i++ {
 		log := fmt.Sprintf("#%d", i+1)
@@ -113,3 +218,542 @@ func testLogs(l *Logger, n int) (err error) {
 
 	return l.Close()
 }
+
+func TestMaxBytes(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// Each entry is 23 bytes (19 byte timestamp, separator, 2 byte message, newline)
+	l.SetMaxBytes(50)
+
+	filename := l.f.Name()
+	for i := 0; i < 2; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if l.f.Name() != filename || l.size != 46 {
+		t.Fatalf("unexpected rotation, file size is %d", l.size)
+	}
+
+	if err = l.LogString("#3"); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.f.Name() == filename || l.size != 0 {
+		t.Fatal("expected rotation after reaching byte limit")
+	}
+
+	filename = l.f.Name()
+	if err = l.LogString(strings.Repeat("a", 100)); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.f.Name() == filename {
+		t.Fatal("expected rotation after writing a message larger than the byte limit")
+	}
+}
+
+func TestSetNumLines(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	for i := 0; i < 3; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filename := l.f.Name()
+	// Raising the limit above the current count should not rotate
+	l.SetNumLines(5)
+	if l.f.Name() != filename || l.count != 3 {
+		t.Fatalf("unexpected rotation, line count is %d", l.count)
+	}
+
+	// Unlimited should not rotate
+	l.SetNumLines(0)
+	if l.f.Name() != filename || l.count != 3 {
+		t.Fatalf("unexpected rotation, line count is %d", l.count)
+	}
+
+	// Lowering the limit below the current count should rotate immediately
+	l.SetNumLines(2)
+	if l.f.Name() == filename || l.count != 0 {
+		t.Fatal("expected rotation after lowering the line limit below the current count")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	std := log.New(l, "", 0)
+	std.Println("#1")
+	std.Printf("#2\n#3")
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logs) != 3 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 3, len(logs))
+	}
+
+	for i, log := range logs {
+		if expected := fmt.Sprintf("#%d", i+1); log != expected {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected, log)
+		}
+	}
+}
+
+func TestRotationHook(t *testing.T) {
+	var (
+		l *Logger
+
+		rotations [][2]string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.SetNumLines(1)
+	l.SetRotationHook(func(oldPath, newPath string) {
+		// Ensure the lock has been released before the hook is called
+		if err := l.Flush(); err != nil {
+			t.Error(err)
+		}
+
+		rotations = append(rotations, [2]string{oldPath, newPath})
+	})
+
+	first := l.f.Name()
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	second := l.f.Name()
+	if len(rotations) != 1 {
+		t.Fatalf("invalid number of rotations, expected %d and received %d", 1, len(rotations))
+	}
+
+	if rotations[0][0] != getAbsPath(first) || rotations[0][1] != getAbsPath(second) {
+		t.Fatalf("invalid rotation paths: %v", rotations[0])
+	}
+
+	if !filepath.IsAbs(rotations[0][0]) || !filepath.IsAbs(rotations[0][1]) {
+		t.Fatalf("expected absolute paths: %v", rotations[0])
+	}
+
+	l.SetRotationHook(nil)
+	if err = l.LogString("#2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rotations) != 1 {
+		t.Fatalf("invalid number of rotations, expected %d and received %d", 1, len(rotations))
+	}
+}
+
+func TestLogf(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Logf("%s #%d %v", "entry", 1, []int{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogStringf("%s #%d %v", "entry", 2, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Logf("%s", "bad\nmessage"); err != ErrMessageContainsNewline {
+		t.Fatalf("invalid error, expected %v and received %v", ErrMessageContainsNewline, err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"entry #1 [1 2]", "entry #2 true"}
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
+
+func TestRotate(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.f.Name() == filename {
+		t.Fatal("expected rotation of empty file")
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename = l.f.Name()
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.f.Name() == filename {
+		t.Fatal("expected rotation of file")
+	}
+
+	if _, err = os.Stat(filename); err != nil {
+		t.Fatalf("expected rotated file to remain: %v", err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Rotate(); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+}
+
+func TestSetBufferSize(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetBufferSize(0); err != ErrInvalidBufferSize {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidBufferSize, err)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetBufferSize(1 << 16); err != nil {
+		t.Fatal(err)
+	}
+
+	if size := l.w.Size(); size != 1<<16 {
+		t.Fatalf("invalid buffer size, expected %d and received %d", 1<<16, size)
+	}
+
+	if l.size == 0 || l.w.Buffered() != 0 {
+		t.Fatal("expected existing buffer to be flushed")
+	}
+
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if size := l.w.Size(); size != 1<<16 {
+		t.Fatalf("invalid buffer size after rotation, expected %d and received %d", 1<<16, size)
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	var (
+		l *Logger
+
+		errC = make(chan error, 1)
+
+		err error
+	)
+
+	dir := path.Join(testDir, "errors")
+	if err = os.MkdirAll(dir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(dir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.SetErrorHandler(func(err error) {
+		// Ensure the lock has been released before the handler is called
+		l.Stats()
+
+		select {
+		case errC <- err:
+		default:
+		}
+	})
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove the directory so the next rotation fails
+	if err = os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetRotateInterval(time.Millisecond * 10); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err = <-errC:
+		if !strings.Contains(err.Error(), "error rotating file") {
+			t.Fatalf("invalid error: %v", err)
+		}
+
+	case <-time.After(time.Second):
+		t.Fatal("expected error handler to be called")
+	}
+
+	l.SetErrorHandler(nil)
+	if l.errorHandler() == nil {
+		t.Fatal("expected default error handler")
+	}
+}
+
+func TestCloseWithTimeout(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Hold the lock to simulate a close which is stuck flushing
+	l.mu.Lock()
+	if err = l.CloseWithTimeout(time.Millisecond * 20); err != ErrCloseTimeout {
+		t.Fatalf("invalid error, expected %v and received %v", ErrCloseTimeout, err)
+	}
+
+	if !l.isClosed() {
+		t.Fatal("expected logger to be closed")
+	}
+
+	filename := l.f.Name()
+	l.mu.Unlock()
+
+	// Close continues in the background once the lock is released
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		l.mu.Lock()
+		closed := l.f == nil
+		l.mu.Unlock()
+
+		if closed {
+			break
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	var info os.FileInfo
+	if info, err = os.Stat(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Size() == 0 {
+		t.Fatal("expected entry to be flushed after the timeout")
+	}
+
+	if err = l.CloseWithTimeout(time.Second); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+}
+
+func TestCloseFile_flush_error(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	f := l.f
+	// Replace the buffer with one which fails on flush
+	l.w = bufio.NewWriter(errWriter{})
+	l.w.WriteString("#1\n")
+
+	if err = l.Close(); err != errTestFlush {
+		t.Fatalf("invalid error, expected %v and received %v", errTestFlush, err)
+	}
+
+	// File was closed despite the flush failing
+	if _, err = f.WriteString("#2\n"); err == nil {
+		t.Fatal("expected file to be closed")
+	}
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	// Close the file ahead of time so both the flush and close fail
+	l.f.Close()
+	l.w = bufio.NewWriter(errWriter{})
+	l.w.WriteString("#1\n")
+
+	err = l.Close()
+	list, ok := err.(*errors.ErrorList)
+	if !ok {
+		t.Fatalf("invalid error type, expected %T and received %T", list, err)
+	}
+
+	var flushFailed, closeFailed bool
+	list.ForEach(func(err error) (end bool) {
+		switch {
+		case err == errTestFlush:
+			flushFailed = true
+		case strings.Contains(err.Error(), "close"):
+			closeFailed = true
+		}
+
+		return
+	})
+
+	if !flushFailed || !closeFailed {
+		t.Fatalf("invalid error, expected flush and close errors and received %v", err)
+	}
+}
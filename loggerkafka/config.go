@@ -0,0 +1,111 @@
+package loggerkafka
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// writerConfig is the parsed configuration of a writer
+type writerConfig struct {
+	sarama *sarama.Config
+
+	batchSize int
+	linger    time.Duration
+}
+
+// parseConfig will parse the provided config map
+func parseConfig(config map[string]string) (c *writerConfig, err error) {
+	c = &writerConfig{
+		sarama:    sarama.NewConfig(),
+		batchSize: defaultBatchSize,
+		linger:    defaultLinger,
+	}
+
+	// Successes must be returned for use with a sync producer
+	c.sarama.Producer.Return.Successes = true
+
+	for key, value := range config {
+		if err = c.set(key, value); err != nil {
+			return nil, fmt.Errorf("invalid kafka config \"%s\": %v", key, err)
+		}
+	}
+
+	if err = c.sarama.Validate(); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// set will set the value of a config key
+func (c *writerConfig) set(key, value string) (err error) {
+	cfg := c.sarama
+	switch key {
+	case "client.id":
+		cfg.ClientID = value
+	case "version":
+		cfg.Version, err = sarama.ParseKafkaVersion(value)
+	case "acks":
+		cfg.Producer.RequiredAcks, err = parseAcks(value)
+	case "compression.type":
+		cfg.Producer.Compression, err = parseCompression(value)
+	case "sasl.username":
+		cfg.Net.SASL.Enable = true
+		cfg.Net.SASL.User = value
+	case "sasl.password":
+		cfg.Net.SASL.Enable = true
+		cfg.Net.SASL.Password = value
+	case "tls":
+		cfg.Net.TLS.Enable, err = strconv.ParseBool(value)
+	case "batch.num.messages":
+		if c.batchSize, err = strconv.Atoi(value); err == nil && c.batchSize < 1 {
+			err = fmt.Errorf("batch size must be greater than zero")
+		}
+	case "linger.ms":
+		var ms int
+		if ms, err = strconv.Atoi(value); err == nil && ms < 1 {
+			err = fmt.Errorf("linger must be greater than zero")
+		}
+
+		c.linger = time.Duration(ms) * time.Millisecond
+	default:
+		err = fmt.Errorf("unsupported key")
+	}
+
+	return
+}
+
+// parseAcks will parse the required acknowledgements
+func parseAcks(value string) (acks sarama.RequiredAcks, err error) {
+	switch value {
+	case "all", "-1":
+		return sarama.WaitForAll, nil
+	case "1":
+		return sarama.WaitForLocal, nil
+	case "0":
+		return sarama.NoResponse, nil
+	default:
+		return 0, fmt.Errorf("acks must be one of all, 1 or 0")
+	}
+}
+
+// parseCompression will parse the compression codec
+func parseCompression(value string) (codec sarama.CompressionCodec, err error) {
+	switch value {
+	case "none":
+		return sarama.CompressionNone, nil
+	case "gzip":
+		return sarama.CompressionGZIP, nil
+	case "snappy":
+		return sarama.CompressionSnappy, nil
+	case "lz4":
+		return sarama.CompressionLZ4, nil
+	case "zstd":
+		return sarama.CompressionZSTD, nil
+	default:
+		return 0, fmt.Errorf("compression type must be one of none, gzip, snappy, lz4 or zstd")
+	}
+}
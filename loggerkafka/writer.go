@@ -0,0 +1,204 @@
+// Package loggerkafka provides a writer which publishes log entries to a Kafka topic
+package loggerkafka
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/gdbu/logger"
+	"github.com/hatchify/errors"
+)
+
+const (
+	// defaultBatchSize is the number of entries batched while the logger is in async mode
+	defaultBatchSize = 100
+	// defaultLinger is the maximum time entries are batched before they are published
+	defaultLinger = time.Second
+)
+
+// newline as a byteslice
+var newline = []byte("\n")
+
+// Producer publishes messages to Kafka, it is satisfied by sarama.SyncProducer
+type Producer interface {
+	SendMessages(msgs []*sarama.ProducerMessage) error
+	Close() error
+}
+
+// NewKafkaWriter will return a writer which publishes each entry of the provided logger to a Kafka topic
+// Note: The writer should be set as the logger's tee (E.g. l.SetTee(w)). Entries are published with the logger name as
+// the message key and the raw entry as the value. The supported config keys are:
+//   - client.id: Client ID sent to the brokers
+//   - version: Kafka version of the brokers (E.g. 2.8.0)
+//   - acks: Required acknowledgements, one of all, 1 or 0 (defaults to 1)
+//   - compression.type: One of none, gzip, snappy, lz4 or zstd (defaults to none)
+//   - sasl.username and sasl.password: Enables SASL/PLAIN authentication
+//   - tls: Enables TLS when set to true
+//   - batch.num.messages: Number of entries batched while the logger is in async mode (defaults to 100)
+//   - linger.ms: Maximum time entries are batched before they are published (defaults to 1000)
+//
+// The parent logger is provided, as the writer needs its name for the message key, its async mode for batching and its
+// error handler for delivery errors which occur outside of a Write call. A *Writer is returned rather than an io.Writer
+// so FlushKafka and Close are available without a type assertion
+func NewKafkaWriter(l *logger.Logger, brokers []string, topic string, config map[string]string) (wp *Writer, err error) {
+	var c *writerConfig
+	if c, err = parseConfig(config); err != nil {
+		return
+	}
+
+	var p sarama.SyncProducer
+	if p, err = sarama.NewSyncProducer(brokers, c.sarama); err != nil {
+		return
+	}
+
+	wp = newWriter(l, p, topic, c.batchSize, c.linger)
+	return
+}
+
+// newWriter will return a new writer for the provided producer
+func newWriter(l *logger.Logger, p Producer, topic string, batchSize int, linger time.Duration) *Writer {
+	var w Writer
+	w.l = l
+	w.p = p
+	w.topic = topic
+	w.batchSize = batchSize
+	w.done = make(chan struct{})
+	go w.flushLoop(linger)
+	return &w
+}
+
+// Writer publishes log entries to a Kafka topic
+// Note: While the logger is in async mode, entries are batched until the batch is full, the linger interval elapses
+// or FlushKafka is called. Otherwise, each entry is published before Write returns
+type Writer struct {
+	mu sync.Mutex
+
+	l *logger.Logger
+	p Producer
+
+	topic string
+
+	// Maximum number of entries per batch
+	batchSize int
+	// Entries waiting to be published
+	batch []*sarama.ProducerMessage
+
+	// Closed when the writer is closed, stops the flush loop
+	done chan struct{}
+	// Closed state
+	closed bool
+}
+
+// Write will publish each entry within bs
+// Note: Delivery errors are returned, which the logger passes to its error handler when the writer is used as a tee
+func (w *Writer) Write(bs []byte) (n int, err error) {
+	// Acquire lock
+	w.mu.Lock()
+	// Defer the release of our lock
+	defer w.mu.Unlock()
+
+	if w.closed {
+		// Writer has been closed, return
+		return 0, errors.ErrIsClosed
+	}
+
+	for _, line := range bytes.Split(bytes.TrimSuffix(bs, newline), newline) {
+		if len(line) == 0 {
+			continue
+		}
+
+		// Copy line, as the logger re-uses its entry buffer once we return
+		w.batch = append(w.batch, w.newMessage(append([]byte(nil), line...)))
+	}
+
+	if w.l.IsAsync() && len(w.batch) < w.batchSize {
+		// Logger is in async mode and the batch is not full, return
+		return len(bs), nil
+	}
+
+	if err = w.flush(); err != nil {
+		return
+	}
+
+	return len(bs), nil
+}
+
+// FlushKafka will publish any batched entries
+func (w *Writer) FlushKafka() (err error) {
+	// Acquire lock
+	w.mu.Lock()
+	// Defer the release of our lock
+	defer w.mu.Unlock()
+
+	if w.closed {
+		// Writer has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	return w.flush()
+}
+
+// Close will publish any batched entries and close the producer
+func (w *Writer) Close() (err error) {
+	// Acquire lock
+	w.mu.Lock()
+	// Defer the release of our lock
+	defer w.mu.Unlock()
+
+	if w.closed {
+		// Writer has already been closed, return
+		return errors.ErrIsClosed
+	}
+
+	w.closed = true
+	close(w.done)
+
+	var errs errors.ErrorList
+	errs.Push(w.flush())
+	errs.Push(w.p.Close())
+	return errs.Err()
+}
+
+// newMessage will return a new producer message for the provided entry
+func (w *Writer) newMessage(value []byte) *sarama.ProducerMessage {
+	var msg sarama.ProducerMessage
+	msg.Topic = w.topic
+	msg.Key = sarama.StringEncoder(w.l.Name())
+	msg.Value = sarama.ByteEncoder(value)
+	return &msg
+}
+
+// flush will publish the batched entries
+// Note: This function expects the lock to be held by the caller. The batch is dropped when publishing fails, so a
+// failing broker does not cause the batch to grow without bound
+func (w *Writer) flush() (err error) {
+	if len(w.batch) == 0 {
+		// Nothing to publish, return
+		return
+	}
+
+	batch := w.batch
+	w.batch = nil
+	return w.p.SendMessages(batch)
+}
+
+// flushLoop will publish batched entries at the provided interval until the writer is closed
+func (w *Writer) flushLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-w.done:
+			return
+		}
+
+		if err := w.FlushKafka(); err != nil && err != errors.ErrIsClosed {
+			// Publishing happened outside of a caller's request, pass error to the logger's error handler
+			w.l.HandleError(err)
+		}
+	}
+}
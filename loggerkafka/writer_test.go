@@ -0,0 +1,234 @@
+package loggerkafka
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/gdbu/logger"
+	"github.com/hatchify/errors"
+)
+
+const (
+	testDir  = "test_data"
+	testName = "testing"
+)
+
+const errTestSend = errors.Error("test send error")
+
+func TestWriter(t *testing.T) {
+	var (
+		l *logger.Logger
+		p mockProducer
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = logger.New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	w := newWriter(l, &p, "logs", defaultBatchSize, time.Hour)
+	defer w.Close()
+
+	if err = l.SetTee(w); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each entry is published before Write returns
+	if len(p.calls) != 3 {
+		t.Fatalf("invalid number of sends, expected %d and received %d", 3, len(p.calls))
+	}
+
+	for i, msgs := range p.calls {
+		if len(msgs) != 1 {
+			t.Fatalf("invalid number of messages, expected %d and received %d", 1, len(msgs))
+		}
+
+		msg := msgs[0]
+		if msg.Topic != "logs" {
+			t.Fatalf("invalid topic, expected \"%s\" and received \"%s\"", "logs", msg.Topic)
+		}
+
+		if key := string(msg.Key.(sarama.StringEncoder)); key != testName {
+			t.Fatalf("invalid key, expected \"%s\" and received \"%s\"", testName, key)
+		}
+
+		expected := fmt.Sprintf("@#%d", i+1)
+		if value := string(msg.Value.(sarama.ByteEncoder)); !strings.HasSuffix(value, expected) {
+			t.Fatalf("invalid value, expected suffix of \"%s\" and received \"%s\"", expected, value)
+		}
+	}
+}
+
+func TestWriter_async(t *testing.T) {
+	var (
+		l *logger.Logger
+		p mockProducer
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = logger.New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	w := newWriter(l, &p, "logs", 5, time.Hour)
+	if err = l.SetTee(w); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetAsync(100); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 12; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Close logger to ensure the async queue has been drained
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = w.FlushKafka(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []int{5, 5, 2}
+	if len(p.calls) != len(expected) {
+		t.Fatalf("invalid number of sends, expected %d and received %d", len(expected), len(p.calls))
+	}
+
+	for i, msgs := range p.calls {
+		if len(msgs) != expected[i] {
+			t.Fatalf("invalid number of messages, expected %d and received %d", expected[i], len(msgs))
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !p.closed {
+		t.Fatal("expected producer to be closed")
+	}
+}
+
+func TestWriter_error(t *testing.T) {
+	var (
+		l *logger.Logger
+		p mockProducer
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = logger.New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	errC := make(chan error, 1)
+	l.SetErrorHandler(func(err error) {
+		errC <- err
+	})
+
+	p.err = errTestSend
+	w := newWriter(l, &p, "logs", defaultBatchSize, time.Hour)
+	defer w.Close()
+
+	if err = l.SetTee(w); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("hello world"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err = <-errC:
+		if err != errTestSend {
+			t.Fatalf("invalid error, expected %v and received %v", errTestSend, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected delivery error to be passed to the error handler")
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	type testcase struct {
+		config  map[string]string
+		wantErr bool
+	}
+
+	tcs := []testcase{
+		{config: nil},
+		{config: map[string]string{"client.id": "logger", "acks": "all", "compression.type": "gzip"}},
+		{config: map[string]string{"batch.num.messages": "10", "linger.ms": "50"}},
+		{config: map[string]string{"acks": "2"}, wantErr: true},
+		{config: map[string]string{"batch.num.messages": "0"}, wantErr: true},
+		{config: map[string]string{"unknown": "value"}, wantErr: true},
+	}
+
+	for _, tc := range tcs {
+		c, err := parseConfig(tc.config)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("invalid error for %v, expected error %v and received %v", tc.config, tc.wantErr, err)
+		}
+
+		if err == nil && !c.sarama.Producer.Return.Successes {
+			t.Fatal("expected successes to be returned for use with a sync producer")
+		}
+	}
+}
+
+// mockProducer records the messages sent to it
+type mockProducer struct {
+	mu sync.Mutex
+
+	calls  [][]*sarama.ProducerMessage
+	err    error
+	closed bool
+}
+
+func (m *mockProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, msgs)
+	return m.err
+}
+
+func (m *mockProducer) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
@@ -0,0 +1,79 @@
+// Package loggerlogrus provides a logrus hook which writes logrus entries to a logger
+package loggerlogrus
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gdbu/logger"
+	"github.com/gdbu/logger/internal/logfmt"
+	"github.com/sirupsen/logrus"
+)
+
+// NewLogrusHook will return a hook which writes logrus entries of the provided levels to the logger
+// Note: A nil levels slice will fire for all levels
+func NewLogrusHook(l *logger.Logger, levels []logrus.Level) *LogrusHook {
+	if levels == nil {
+		levels = logrus.AllLevels
+	}
+
+	var h LogrusHook
+	h.l = l
+	h.levels = levels
+	return &h
+}
+
+// LogrusHook is a logrus.Hook which writes entries as logfmt lines to a logger
+type LogrusHook struct {
+	l      *logger.Logger
+	levels []logrus.Level
+}
+
+// Levels will return the levels the hook fires for
+func (h *LogrusHook) Levels() []logrus.Level {
+	return h.levels
+}
+
+// Fire will write the entry to the logger as a logfmt line (E.g. level=info msg="hello world" key=value)
+// Note: Fields are written in key order
+func (h *LogrusHook) Fire(e *logrus.Entry) error {
+	buf := make([]byte, 0, 64+len(e.Message))
+	buf = appendPair(buf, "level", e.Level.String())
+	buf = append(buf, ' ')
+	buf = appendPair(buf, "msg", e.Message)
+
+	keys := make([]string, 0, len(e.Data))
+	for key := range e.Data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		buf = append(buf, ' ')
+		buf = appendPair(buf, key, formatValue(e.Data[key]))
+	}
+
+	return h.l.Log(buf)
+}
+
+// formatValue will format a field value as a string
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case error:
+		return v.Error()
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// appendPair will append a key=value pair, see logfmt.AppendValue
+func appendPair(buf []byte, key, value string) []byte {
+	buf = append(buf, key...)
+	buf = append(buf, '=')
+	return logfmt.AppendValue(buf, value)
+}
@@ -0,0 +1,86 @@
+package loggerlogrus
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gdbu/logger"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	testDir  = "test_data"
+	testName = "testing"
+)
+
+func TestLogrusHook(t *testing.T) {
+	var (
+		l *logger.Logger
+		r *logger.Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = logger.New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	lr := logrus.New()
+	lr.SetOutput(ioutil.Discard)
+	lr.SetLevel(logrus.DebugLevel)
+	lr.AddHook(NewLogrusHook(l, []logrus.Level{logrus.ErrorLevel, logrus.WarnLevel, logrus.InfoLevel}))
+
+	lr.Debug("not hooked")
+	lr.WithFields(logrus.Fields{"method": "GET", "status": 200}).Info("request handled")
+	lr.WithField("user", "jane doe").Warn("slow request")
+	lr.WithError(errors.New("connection reset")).Error("request failed")
+	lr.WithField("ctrl", "a\x01b").Info("multi\nline")
+
+	var files []string
+	if files, err = l.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = logger.NewReader(files[0]); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`level=info msg="request handled" method=GET status=200`,
+		`level=warning msg="slow request" user="jane doe"`,
+		`level=error msg="request failed" error="connection reset"`,
+		`level=info msg="multi\nline" ctrl="a\u0001b"`,
+	}
+
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Package loggerotel provides trace-correlated logging using OpenTelemetry span contexts
+package loggerotel
+
+import (
+	"context"
+
+	"github.com/gdbu/logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// New will return a logger which correlates entries with the OpenTelemetry span of the provided context
+func New(l *logger.Logger) *Logger {
+	var t Logger
+	t.Logger = l
+	return &t
+}
+
+// Logger wraps a logger to provide trace-correlated logging, all logger methods remain available
+type Logger struct {
+	*logger.Logger
+}
+
+// LogWithContext will log a message prepended with the trace and span IDs of the span within the provided context
+// (E.g. trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7 message)
+// Note: When the context has no valid span, the message is logged without the IDs. Unlike LogContext, the context is
+// not used for cancellation
+func (t *Logger) LogWithContext(ctx context.Context, msg []byte) (err error) {
+	return t.Log(appendTraceFields(ctx, nil, msg))
+}
+
+// appendTraceFields will append the trace and span IDs of the span within the provided context followed by the message
+// to the provided buffer, the IDs are omitted when the context has no valid span
+func appendTraceFields(ctx context.Context, buf, msg []byte) []byte {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		// Context does not have an active span, return
+		return append(buf, msg...)
+	}
+
+	buf = append(buf, "trace_id="...)
+	buf = append(buf, sc.TraceID().String()...)
+	buf = append(buf, " span_id="...)
+	buf = append(buf, sc.SpanID().String()...)
+	buf = append(buf, ' ')
+	return append(buf, msg...)
+}
@@ -0,0 +1,98 @@
+package loggerotel
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gdbu/logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	testDir  = "test_data"
+	testName = "testing"
+)
+
+func TestLogger_LogWithContext(t *testing.T) {
+	var (
+		l *logger.Logger
+		r *logger.Reader
+
+		traceID trace.TraceID
+		spanID  trace.SpanID
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = logger.New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if traceID, err = trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736"); err != nil {
+		t.Fatal(err)
+	}
+
+	if spanID, err = trace.SpanIDFromHex("00f067aa0ba902b7"); err != nil {
+		t.Fatal(err)
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	tl := New(l)
+	if err = tl.LogWithContext(trace.ContextWithSpanContext(context.Background(), sc), []byte("hello world")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = tl.LogWithContext(context.Background(), []byte("no span")); err != nil {
+		t.Fatal(err)
+	}
+
+	var files []string
+	if files, err = l.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = logger.NewReader(files[0]); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7 hello world",
+		"no span",
+	}
+
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of logs, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package loggerprom provides a Prometheus collector which exports the metrics of a logger
+package loggerprom
+
+import (
+	"github.com/gdbu/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// namespace is the namespace of all exported metrics
+const namespace = "logger"
+
+// labels are the variable labels of all exported metrics
+var labels = []string{"dir", "name"}
+
+var (
+	totalLinesWrittenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "total_lines_written"),
+		"Total number of lines written.",
+		labels, nil,
+	)
+
+	totalBytesWrittenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "total_bytes_written"),
+		"Total number of bytes written.",
+		labels, nil,
+	)
+
+	totalRotationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "total_rotations"),
+		"Total number of file rotations.",
+		labels, nil,
+	)
+
+	currentFileSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "current_file_size"),
+		"Size of the current file in bytes.",
+		labels, nil,
+	)
+
+	currentLineCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "current_line_count"),
+		"Number of lines within the current file.",
+		labels, nil,
+	)
+)
+
+// NewCollector will return a new collector for the provided logger
+func NewCollector(l *logger.Logger) *LoggerCollector {
+	var c LoggerCollector
+	c.l = l
+	return &c
+}
+
+// LoggerCollector is a prometheus.Collector which exports the metrics of a logger
+type LoggerCollector struct {
+	l *logger.Logger
+}
+
+// Describe will send the descriptors of all exported metrics to the provided channel
+func (c *LoggerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- totalLinesWrittenDesc
+	ch <- totalBytesWrittenDesc
+	ch <- totalRotationsDesc
+	ch <- currentFileSizeDesc
+	ch <- currentLineCountDesc
+}
+
+// Collect will send a snapshot of the logger's stats to the provided channel
+func (c *LoggerCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.l.Stats()
+	dir, name := c.l.Dir(), c.l.Name()
+
+	ch <- prometheus.MustNewConstMetric(totalLinesWrittenDesc, prometheus.CounterValue, float64(stats.TotalLinesWritten), dir, name)
+	ch <- prometheus.MustNewConstMetric(totalBytesWrittenDesc, prometheus.CounterValue, float64(stats.TotalBytesWritten), dir, name)
+	ch <- prometheus.MustNewConstMetric(totalRotationsDesc, prometheus.CounterValue, float64(stats.TotalRotations), dir, name)
+	ch <- prometheus.MustNewConstMetric(currentFileSizeDesc, prometheus.GaugeValue, float64(stats.CurrentFileSize), dir, name)
+	ch <- prometheus.MustNewConstMetric(currentLineCountDesc, prometheus.GaugeValue, float64(stats.CurrentLineCount), dir, name)
+}
@@ -0,0 +1,65 @@
+package loggerprom
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gdbu/logger"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const (
+	testDir  = "test_data"
+	testName = "testing"
+)
+
+func TestCollector(t *testing.T) {
+	var (
+		l *logger.Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = logger.New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.SetNumLines(2)
+
+	// Each entry is 23 bytes (19 byte timestamp, separator, 2 byte message, newline)
+	for i := 0; i < 3; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := `
+# HELP logger_current_file_size Size of the current file in bytes.
+# TYPE logger_current_file_size gauge
+logger_current_file_size{dir="test_data",name="testing"} 23
+# HELP logger_current_line_count Number of lines within the current file.
+# TYPE logger_current_line_count gauge
+logger_current_line_count{dir="test_data",name="testing"} 1
+# HELP logger_total_bytes_written Total number of bytes written.
+# TYPE logger_total_bytes_written counter
+logger_total_bytes_written{dir="test_data",name="testing"} 69
+# HELP logger_total_lines_written Total number of lines written.
+# TYPE logger_total_lines_written counter
+logger_total_lines_written{dir="test_data",name="testing"} 3
+# HELP logger_total_rotations Total number of file rotations.
+# TYPE logger_total_rotations counter
+logger_total_rotations{dir="test_data",name="testing"} 1
+`
+
+	if err = testutil.CollectAndCompare(NewCollector(l), strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+}
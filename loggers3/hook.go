@@ -0,0 +1,124 @@
+// Package loggers3 provides a rotation hook which uploads rotated log files to an AWS S3 bucket
+package loggers3
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/gdbu/logger"
+)
+
+const (
+	// maxRetries is the maximum number of times an upload is retried after a transient error
+	maxRetries = 3
+	// baseBackoff is the delay before the first retry, doubling for each subsequent retry
+	baseBackoff = time.Second
+)
+
+// S3Client uploads objects to S3, it is satisfied by *s3.Client
+type S3Client interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// Options are the options of an upload hook
+type Options struct {
+	// Remove the local file once it has been uploaded
+	DeleteAfterUpload bool
+	// Called when an upload fails after all retries (defaults to printing the error to stdout)
+	OnError logger.ErrorFn
+}
+
+// NewS3UploadHook will return a rotation hook which uploads each rotated file to s3://bucket/prefix/filename
+// Note: Uploads occur within a goroutine so rotations are not blocked. The hook receives the uncompressed path of the
+// rotated file, so it should not be combined with compress on rotate
+func NewS3UploadHook(bucket, prefix string, cfg aws.Config, opts Options) logger.RotationHook {
+	return newUploadHook(s3.NewFromConfig(cfg), bucket, prefix, opts)
+}
+
+// newUploadHook will return a rotation hook which uploads rotated files with the provided client
+func newUploadHook(client S3Client, bucket, prefix string, opts Options) logger.RotationHook {
+	var u uploader
+	u.client = client
+	u.bucket = bucket
+	u.prefix = prefix
+	u.opts = opts
+	u.backoff = baseBackoff
+
+	if u.opts.OnError == nil {
+		u.opts.OnError = func(err error) {
+			fmt.Printf("loggers3 :: %v\n", err)
+		}
+	}
+
+	return func(oldPath, newPath string) {
+		go u.upload(oldPath)
+	}
+}
+
+// uploader uploads rotated files to S3
+type uploader struct {
+	client S3Client
+
+	bucket string
+	prefix string
+	opts   Options
+
+	// Delay before the first retry
+	backoff time.Duration
+}
+
+// upload will upload a file to S3, retrying transient errors with exponential backoff
+func (u *uploader) upload(filename string) {
+	var err error
+	backoff := u.backoff
+	for i := 0; ; i++ {
+		if err = u.put(filename); err == nil {
+			break
+		}
+
+		if i == maxRetries || !isTransient(err) {
+			u.opts.OnError(fmt.Errorf("error uploading \"%s\": %v", filename, err))
+			return
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	if !u.opts.DeleteAfterUpload {
+		return
+	}
+
+	if err = os.Remove(filename); err != nil {
+		u.opts.OnError(fmt.Errorf("error removing uploaded file: %v", err))
+	}
+}
+
+// put will upload a file to S3 once
+func (u *uploader) put(filename string) (err error) {
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+	defer f.Close()
+
+	var input s3.PutObjectInput
+	input.Bucket = aws.String(u.bucket)
+	input.Key = aws.String(path.Join(u.prefix, filepath.Base(filename)))
+	input.Body = f
+
+	_, err = u.client.PutObject(context.Background(), &input)
+	return
+}
+
+// isTransient will return whether or not an error is transient, using the SDK's retry classification
+func isTransient(err error) bool {
+	return retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary
+}
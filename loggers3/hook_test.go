@@ -0,0 +1,178 @@
+package loggers3
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/gdbu/logger"
+	"github.com/hatchify/errors"
+)
+
+const (
+	testDir  = "test_data"
+	testName = "testing"
+)
+
+const errTestPermanent = errors.Error("test permanent error")
+
+func TestNewS3UploadHook(t *testing.T) {
+	var (
+		l *logger.Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = logger.New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	client := newMockClient()
+	l.SetRotationHook(newUploadHook(client, "bucket", "logs", Options{DeleteAfterUpload: true}))
+
+	if err = l.LogString("hello world"); err != nil {
+		t.Fatal(err)
+	}
+
+	var files []string
+	if files, err = l.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated := files[0]
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var put mockPut
+	select {
+	case put = <-client.puts:
+	case <-time.After(time.Second):
+		t.Fatal("expected rotated file to be uploaded")
+	}
+
+	if put.bucket != "bucket" {
+		t.Fatalf("invalid bucket, expected \"%s\" and received \"%s\"", "bucket", put.bucket)
+	}
+
+	if expected := path.Join("logs", path.Base(rotated)); put.key != expected {
+		t.Fatalf("invalid key, expected \"%s\" and received \"%s\"", expected, put.key)
+	}
+
+	if len(put.body) == 0 {
+		t.Fatal("expected uploaded body to contain the rotated file")
+	}
+
+	// The local file is removed once uploaded
+	for i := 0; i < 100; i++ {
+		if _, err = os.Stat(rotated); os.IsNotExist(err) {
+			return
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	t.Fatal("expected rotated file to be removed after upload")
+}
+
+func TestUploader_retry(t *testing.T) {
+	type testcase struct {
+		errs        []error
+		expectedPut int
+		expectedErr bool
+	}
+
+	transient := mockTransientError{}
+	tcs := []testcase{
+		{errs: nil, expectedPut: 1},
+		{errs: []error{transient, transient}, expectedPut: 3},
+		{errs: []error{transient, transient, transient, transient}, expectedPut: 4, expectedErr: true},
+		{errs: []error{errTestPermanent}, expectedPut: 1, expectedErr: true},
+	}
+
+	if err := os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	filename := path.Join(testDir, "testing.log")
+	if err := ioutil.WriteFile(filename, []byte("hello world\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range tcs {
+		var errs []error
+		client := newMockClient()
+		client.errs = tc.errs
+
+		u := uploader{client: client, bucket: "bucket", backoff: time.Millisecond}
+		u.opts.OnError = func(err error) {
+			errs = append(errs, err)
+		}
+
+		u.upload(filename)
+
+		if len(client.puts) != tc.expectedPut {
+			t.Fatalf("invalid number of uploads, expected %d and received %d", tc.expectedPut, len(client.puts))
+		}
+
+		if (len(errs) > 0) != tc.expectedErr {
+			t.Fatalf("invalid errors, expected error %v and received %v", tc.expectedErr, errs)
+		}
+	}
+}
+
+func newMockClient() *mockClient {
+	var m mockClient
+	m.puts = make(chan mockPut, 8)
+	return &m
+}
+
+// mockClient records the objects uploaded to it, returning errs (in order) before succeeding
+type mockClient struct {
+	mu sync.Mutex
+
+	puts chan mockPut
+	errs []error
+}
+
+type mockPut struct {
+	bucket string
+	key    string
+	body   []byte
+}
+
+func (m *mockClient) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	body, err := ioutil.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	m.puts <- mockPut{bucket: *params.Bucket, key: *params.Key, body: body}
+	if len(m.errs) > 0 {
+		err, m.errs = m.errs[0], m.errs[1:]
+		return nil, err
+	}
+
+	return &s3.PutObjectOutput{}, nil
+}
+
+// mockTransientError is classified as retryable by the SDK
+type mockTransientError struct{}
+
+func (mockTransientError) Error() string        { return "test transient error" }
+func (mockTransientError) RetryableError() bool { return true }
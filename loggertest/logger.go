@@ -0,0 +1,192 @@
+// Package loggertest provides a logger for asserting log contents within unit tests
+package loggertest
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gdbu/logger"
+	"github.com/hatchify/errors"
+)
+
+// name is the name of the logger files within the temporary directory
+const name = "test"
+
+// NewTestLogger will return a logger which writes to a temporary directory, the logger is closed and the directory is
+// removed once the test (and its subtests) have completed
+// Note: Options are applied to the logger when it is created, and again when it is reset
+func NewTestLogger(t testing.TB, opts ...logger.Option) *TestLogger {
+	t.Helper()
+
+	var (
+		tl  TestLogger
+		err error
+	)
+
+	if tl.dir, err = ioutil.TempDir("", "loggertest"); err != nil {
+		t.Fatal(err)
+	}
+
+	tl.opts = opts
+	if tl.Logger, err = logger.NewWithOptions(tl.dir, name, opts...); err != nil {
+		os.RemoveAll(tl.dir)
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if tl.Logger != nil {
+			tl.Logger.Close()
+		}
+
+		os.RemoveAll(tl.dir)
+	})
+
+	return &tl
+}
+
+// TestLogger wraps a logger to provide assertions on the logged contents, all logger methods remain available
+// Note: Reset replaces the underlying logger, so it must not be called concurrently with other methods
+type TestLogger struct {
+	*logger.Logger
+
+	// Temporary directory containing the logger files
+	dir  string
+	opts []logger.Option
+}
+
+// flush will flush buffered entries so they are visible, closed loggers have already been flushed
+func (tl *TestLogger) flush() (err error) {
+	if err = tl.Flush(); err == errors.ErrIsClosed {
+		return nil
+	}
+
+	return
+}
+
+// contents will return the contents of all logger files, oldest first
+func (tl *TestLogger) contents() (bs []byte, err error) {
+	if err = tl.flush(); err != nil {
+		return
+	}
+
+	var filenames []string
+	if filenames, err = tl.Files(); err != nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	for _, filename := range filenames {
+		var file []byte
+		if file, err = ioutil.ReadFile(filename); err != nil {
+			return
+		}
+
+		buf.Write(file)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Entries will return all logged entries, oldest first
+// Note: Lines which cannot be parsed are skipped
+func (tl *TestLogger) Entries() (entries []logger.Entry) {
+	if err := tl.flush(); err != nil {
+		// Entries cannot be flushed, return
+		return
+	}
+
+	filenames, err := tl.Files()
+	if err != nil {
+		return
+	}
+
+	for _, filename := range filenames {
+		entries = appendEntries(entries, filename)
+	}
+
+	return
+}
+
+// appendEntries will append the entries of the provided file
+func appendEntries(entries []logger.Entry, filename string) []logger.Entry {
+	r, err := logger.NewReader(filename)
+	if err != nil {
+		return entries
+	}
+	defer r.Close()
+
+	for {
+		var e logger.Entry
+		if e, err = r.Next(); err == io.EOF {
+			return entries
+		} else if _, ok := err.(*logger.ParseError); ok {
+			// Line cannot be parsed, skip
+			continue
+		} else if err != nil {
+			return entries
+		}
+
+		entries = append(entries, e)
+	}
+}
+
+// AssertContains will fail the test if the logged contents do not contain the provided string
+func (tl *TestLogger) AssertContains(t testing.TB, substr string) {
+	t.Helper()
+
+	bs, err := tl.contents()
+	if err != nil {
+		t.Errorf("error reading log contents: %v", err)
+		return
+	}
+
+	if !strings.Contains(string(bs), substr) {
+		t.Errorf("invalid log contents, expected to contain %q and received %q", substr, bs)
+	}
+}
+
+// AssertNotContains will fail the test if the logged contents contain the provided string
+func (tl *TestLogger) AssertNotContains(t testing.TB, substr string) {
+	t.Helper()
+
+	bs, err := tl.contents()
+	if err != nil {
+		t.Errorf("error reading log contents: %v", err)
+		return
+	}
+
+	if strings.Contains(string(bs), substr) {
+		t.Errorf("invalid log contents, expected not to contain %q and received %q", substr, bs)
+	}
+}
+
+// AssertLineCount will fail the test if the number of logged entries does not match the provided count
+func (tl *TestLogger) AssertLineCount(t testing.TB, n int) {
+	t.Helper()
+
+	if count := len(tl.Entries()); count != n {
+		t.Errorf("invalid number of lines, expected %d and received %d", n, count)
+	}
+}
+
+// Reset will remove all logged contents, replacing the logger with a new logger
+func (tl *TestLogger) Reset() (err error) {
+	if err = tl.Logger.Close(); err != nil && err != errors.ErrIsClosed {
+		return
+	}
+
+	if err = os.RemoveAll(tl.dir); err != nil {
+		return
+	}
+
+	if err = os.MkdirAll(tl.dir, 0755); err != nil {
+		return
+	}
+
+	tl.Logger, err = logger.NewWithOptions(tl.dir, name, tl.opts...)
+	return
+}
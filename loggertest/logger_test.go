@@ -0,0 +1,101 @@
+package loggertest
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestTestLogger_AssertContains(t *testing.T) {
+	tl := NewTestLogger(t)
+	if err := tl.LogString("hello world"); err != nil {
+		t.Fatal(err)
+	}
+
+	var rec recorder
+	rec.TB = t
+	tl.AssertContains(&rec, "hello")
+	if rec.failed {
+		t.Fatalf("invalid failed state, expected false and received true: %s", rec.msg)
+	}
+
+	tl.AssertContains(&rec, "goodbye")
+	if !rec.failed {
+		t.Fatal("invalid failed state, expected true and received false")
+	}
+}
+
+func TestTestLogger_AssertNotContains(t *testing.T) {
+	tl := NewTestLogger(t)
+	if err := tl.LogString("hello world"); err != nil {
+		t.Fatal(err)
+	}
+
+	var rec recorder
+	rec.TB = t
+	tl.AssertNotContains(&rec, "goodbye")
+	if rec.failed {
+		t.Fatalf("invalid failed state, expected false and received true: %s", rec.msg)
+	}
+
+	tl.AssertNotContains(&rec, "hello")
+	if !rec.failed {
+		t.Fatal("invalid failed state, expected true and received false")
+	}
+}
+
+func TestTestLogger_Reset(t *testing.T) {
+	tl := NewTestLogger(t)
+	for i := 0; i < 3; i++ {
+		if err := tl.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tl.AssertLineCount(t, 3)
+	entries := tl.Entries()
+	if entries[2].Message != "#3" {
+		t.Fatalf("invalid message, expected %s and received %s", "#3", entries[2].Message)
+	}
+
+	if err := tl.Reset(); err != nil {
+		t.Fatal(err)
+	}
+
+	tl.AssertLineCount(t, 0)
+	tl.AssertNotContains(t, "#1")
+
+	if err := tl.LogString("#4"); err != nil {
+		t.Fatal(err)
+	}
+
+	tl.AssertLineCount(t, 1)
+	tl.AssertContains(t, "#4")
+}
+
+func TestNewTestLogger_cleanup(t *testing.T) {
+	var dir string
+	t.Run("logger", func(t *testing.T) {
+		tl := NewTestLogger(t)
+		dir = tl.dir
+	})
+
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("invalid error, expected %v and received %v", os.ErrNotExist, err)
+	}
+}
+
+// recorder records failures rather than failing the test
+type recorder struct {
+	testing.TB
+
+	failed bool
+	msg    string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failed = true
+	r.msg = fmt.Sprintf(format, args...)
+}
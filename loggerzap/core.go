@@ -0,0 +1,124 @@
+// Package loggerzap provides a zapcore.Core which writes zap entries to a logger
+package loggerzap
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdbu/logger"
+	"github.com/gdbu/logger/internal/logfmt"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewZapCore will return a zap core which writes entries at or above the provided level to the logger
+// Note: Fields are appended to the message as logfmt key=value pairs, and messages containing newlines are quoted.
+// Entries above the error level (DPanic, Panic and Fatal) are written with the error level, zap handles panicking and
+// exiting once the entry has been written
+func NewZapCore(l *logger.Logger, level zapcore.Level) zapcore.Core {
+	var c core
+	c.LevelEnabler = level
+	c.l = l
+	return &c
+}
+
+// core is a zapcore.Core which writes to a logger
+type core struct {
+	zapcore.LevelEnabler
+
+	l *logger.Logger
+	// Pre-encoded fields added with With
+	fields []byte
+}
+
+// With will return a new core with the provided fields encoded
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = appendFields(append([]byte(nil), c.fields...), fields)
+	return &clone
+}
+
+// Check will add the core to the checked entry when the entry's level is enabled
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+// Write will write the entry and fields to the logger
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	msg := make([]byte, 0, len(ent.Message)+len(c.fields))
+	if strings.ContainsAny(ent.Message, "\r\n") {
+		// Message spans multiple lines, write as a quoted logfmt value so the entry remains a single line
+		msg = logfmt.AppendValue(msg, ent.Message)
+	} else {
+		msg = append(msg, ent.Message...)
+	}
+
+	msg = append(msg, c.fields...)
+	msg = appendFields(msg, fields)
+
+	switch {
+	case ent.Level <= zapcore.DebugLevel:
+		return c.l.Debug(msg)
+	case ent.Level == zapcore.InfoLevel:
+		return c.l.Info(msg)
+	case ent.Level == zapcore.WarnLevel:
+		return c.l.Warn(msg)
+	default:
+		return c.l.Error(msg)
+	}
+}
+
+// Sync will flush the logger
+func (c *core) Sync() error {
+	return c.l.Flush()
+}
+
+// appendFields will append the fields to buf as space-prefixed key=value pairs
+func appendFields(buf []byte, fields []zapcore.Field) []byte {
+	for _, f := range fields {
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+
+		// Most fields encode a single key, sort keys so fields which encode several keys are written consistently
+		keys := make([]string, 0, len(enc.Fields))
+		for key := range enc.Fields {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+		for _, key := range keys {
+			buf = append(buf, ' ')
+			buf = append(buf, key...)
+			buf = append(buf, '=')
+			buf = appendValue(buf, enc.Fields[key])
+		}
+	}
+
+	return buf
+}
+
+// appendValue will append a field value as a logfmt value, see logfmt.AppendValue
+func appendValue(buf []byte, value interface{}) []byte {
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case map[string]interface{}, []interface{}:
+		// Objects and arrays are encoded as JSON
+		bs, err := json.Marshal(v)
+		if err != nil {
+			str = fmt.Sprint(v)
+		} else {
+			str = string(bs)
+		}
+	default:
+		str = fmt.Sprint(v)
+	}
+
+	return logfmt.AppendValue(buf, str)
+}
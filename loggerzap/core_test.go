@@ -0,0 +1,86 @@
+package loggerzap
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gdbu/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	testDir  = "test_data"
+	testName = "testing"
+)
+
+func TestNewZapCore(t *testing.T) {
+	var (
+		l *logger.Logger
+		r *logger.Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = logger.New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	z := zap.New(NewZapCore(l, zapcore.InfoLevel))
+	z.Debug("filtered")
+	z.Info("request handled", zap.String("method", "GET"), zap.Int("status", 200), zap.Duration("took", time.Second))
+	z.With(zap.String("service", "api")).Error("request failed", zap.Error(errors.New("connection reset")))
+	z.Warn("quoted", zap.String("empty", ""), zap.String("user", "jane doe"), zap.String("ctrl", "a\x01b"))
+	z.Info("line one\nline two")
+
+	if err = z.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	var files []string
+	if files, err = l.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = logger.NewReader(files[0]); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"INFO @request handled method=GET status=200 took=1s",
+		`ERROR@request failed service=api error="connection reset"`,
+		`WARN @quoted empty= user="jane doe" ctrl="a\u0001b"`,
+		`INFO @"line one\nline two"`,
+	}
+
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+const (
+	// manifestExt is the extension of the manifest file
+	manifestExt = ".manifest.jsonl"
+	// manifestFlag is the flag used to open the manifest file
+	manifestFlag = os.O_WRONLY | os.O_APPEND | os.O_CREATE
+)
+
+const (
+	// rotationReasonNone is used when opening the initial file
+	rotationReasonNone RotationReason = ""
+	// RotationReasonTime is used for rotations caused by the rotate interval or midnight rotation
+	RotationReasonTime RotationReason = "time"
+	// RotationReasonLines is used for rotations caused by the line limit
+	RotationReasonLines RotationReason = "lines"
+	// RotationReasonSize is used for rotations caused by the byte limit
+	RotationReasonSize RotationReason = "size"
+	// RotationReasonManual is used for rotations caused by calling Rotate (E.g. from a signal)
+	RotationReasonManual RotationReason = "manual"
+	// RotationReasonRename is used for rotations caused by calling SetName
+	RotationReasonRename RotationReason = "rename"
+)
+
+// RotationReason represents the cause of a rotation
+type RotationReason string
+
+// ManifestEntry represents a closed log file recorded within the manifest
+type ManifestEntry struct {
+	// Time the file was opened
+	OpenedAt time.Time `json:"openedAt"`
+	// Time the file was closed
+	ClosedAt time.Time `json:"closedAt"`
+	// Path of the file at the time it was closed
+	// Note: The file may have since been compressed, archived or removed by retention
+	Path string `json:"path"`
+	// Number of lines written to the file
+	LineCount int `json:"lineCount"`
+	// Number of bytes written to the file
+	ByteCount int64 `json:"byteCount"`
+	// Cause of the rotation
+	Reason RotationReason `json:"reason"`
+}
+
+// getManifestFilename will return the path of the manifest file (E.g. dir/name.manifest.jsonl)
+func getManifestFilename(dir, name string) string {
+	return path.Join(dir, name+manifestExt)
+}
+
+// newManifestEntry will return an entry for the current file
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) newManifestEntry(reason RotationReason) (e ManifestEntry) {
+	e.OpenedAt = l.openedAt
+	e.ClosedAt = time.Now()
+	e.Path = l.filename
+	e.LineCount = l.count
+	e.ByteCount = l.size
+	e.Reason = reason
+	return
+}
+
+// writeManifestEntry will append the provided entry to the manifest
+// Note: Manifest errors are passed to the error handler once the lock is released, rather than failing the rotation
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) writeManifestEntry(e ManifestEntry) {
+	if err := appendManifestEntry(getManifestFilename(l.dir, l.name), e); err != nil {
+		l.addPending(newErrorCall(l.errorHandler(), fmt.Errorf("error writing manifest: %v", err)))
+	}
+}
+
+// appendManifestEntry will append an entry as a JSON line to the manifest file
+func appendManifestEntry(filename string, e ManifestEntry) (err error) {
+	var bs []byte
+	if bs, err = json.Marshal(e); err != nil {
+		return
+	}
+
+	var f *os.File
+	if f, err = os.OpenFile(filename, manifestFlag, 0644); err != nil {
+		return
+	}
+
+	if _, err = f.Write(append(bs, '\n')); err != nil {
+		f.Close()
+		return
+	}
+
+	return f.Close()
+}
+
+// ReadManifest will return the entries of the manifest file for the provided directory and name
+func ReadManifest(dir, name string) (entries []ManifestEntry, err error) {
+	var f *os.File
+	if f, err = os.Open(getManifestFilename(dir, name)); err != nil {
+		return
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var e ManifestEntry
+		if err = json.Unmarshal(s.Bytes(), &e); err != nil {
+			return
+		}
+
+		entries = append(entries, e)
+	}
+
+	err = s.Err()
+	return
+}
+
+// SetManifestEnabled will set whether or not rotations are recorded within dir/name.manifest.jsonl
+// Note: Each rotation appends a JSON line describing the closed file. The manifest is never rotated
+func (l *Logger) SetManifestEnabled(enabled bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.manifest = enabled
+	return
+}
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestSetManifestEnabled(t *testing.T) {
+	var (
+		l *Logger
+
+		entries []ManifestEntry
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetManifestEnabled(true); err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	logN := func(n int) {
+		for i := 0; i < n; i++ {
+			if len(paths) == 0 || paths[len(paths)-1] != l.filename {
+				paths = append(paths, l.filename)
+			}
+
+			if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Two rotations caused by the line limit
+	l.SetNumLines(2)
+	logN(4)
+	l.SetNumLines(0)
+
+	// One rotation caused by the byte limit, each entry is 23 bytes
+	l.SetMaxBytes(23)
+	logN(1)
+	l.SetMaxBytes(0)
+
+	// Two manual rotations
+	for i := 0; i < 2; i++ {
+		logN(1)
+		if err = l.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if entries, err = ReadManifest(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	type testcase struct {
+		reason    RotationReason
+		lineCount int
+	}
+
+	tcs := []testcase{
+		{reason: RotationReasonLines, lineCount: 2},
+		{reason: RotationReasonLines, lineCount: 2},
+		{reason: RotationReasonSize, lineCount: 1},
+		{reason: RotationReasonManual, lineCount: 1},
+		{reason: RotationReasonManual, lineCount: 1},
+	}
+
+	if len(entries) != len(tcs) {
+		t.Fatalf("invalid number of manifest entries, expected %d and received %d", len(tcs), len(entries))
+	}
+
+	for i, tc := range tcs {
+		e := entries[i]
+		if e.Reason != tc.reason {
+			t.Fatalf("invalid reason, expected \"%s\" and received \"%s\"", tc.reason, e.Reason)
+		}
+
+		if e.LineCount != tc.lineCount {
+			t.Fatalf("invalid line count, expected %d and received %d", tc.lineCount, e.LineCount)
+		}
+
+		if expected := int64(tc.lineCount * 23); e.ByteCount != expected {
+			t.Fatalf("invalid byte count, expected %d and received %d", expected, e.ByteCount)
+		}
+
+		if e.Path != paths[i] {
+			t.Fatalf("invalid path, expected \"%s\" and received \"%s\"", paths[i], e.Path)
+		}
+
+		if e.OpenedAt.IsZero() || e.ClosedAt.Before(e.OpenedAt) {
+			t.Fatalf("invalid times, opened at %v and closed at %v", e.OpenedAt, e.ClosedAt)
+		}
+	}
+
+	// The manifest is not considered a log file
+	var files []string
+	if files, err = l.Files(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, filename := range files {
+		if filename == getManifestFilename(testDir, testName) {
+			t.Fatal("expected manifest to be excluded from the log files")
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+// newMemoryEntry will return a new Entry for the provided formatted entry
+func newMemoryEntry(e entry) (me Entry) {
+	me.Timestamp = time.Now().UnixNano()
+	me.Level = e.level.name()
+	me.Message = string(e.prefix) + string(e.msg)
+	me.Hostname = e.hostname
+	me.PID = e.pid
+	me.Caller = e.caller
+	me.Stack = string(e.stack)
+	if len(e.fields) == 0 {
+		return
+	}
+
+	me.Extra = make(map[string]string, len(e.fields))
+	for _, f := range e.fields {
+		me.Extra[f.key] = f.value
+	}
+
+	return
+}
+
+// newMemoryBuffer will return a new memory buffer with the provided capacity
+func newMemoryBuffer(n int) *memoryBuffer {
+	var m memoryBuffer
+	m.entries = make([]Entry, n)
+	return &m
+}
+
+// memoryBuffer is a fixed size ring buffer of the most recent entries
+type memoryBuffer struct {
+	mu sync.Mutex
+
+	entries []Entry
+	// Index of the next entry to be written
+	next int
+	// Whether or not the buffer has wrapped
+	full bool
+}
+
+// push will add an entry, replacing the oldest entry when the buffer is full
+func (m *memoryBuffer) push(e Entry) {
+	// Acquire lock
+	m.mu.Lock()
+	// Defer the release of our lock
+	defer m.mu.Unlock()
+
+	m.entries[m.next] = e
+	if m.next = (m.next + 1) % len(m.entries); m.next == 0 {
+		m.full = true
+	}
+}
+
+// snapshot will return a copy of the entries, ordered from oldest to newest
+func (m *memoryBuffer) snapshot() (entries []Entry) {
+	// Acquire lock
+	m.mu.Lock()
+	// Defer the release of our lock
+	defer m.mu.Unlock()
+
+	if !m.full {
+		// Buffer has not wrapped, entries begin at the start of the buffer
+		return append(entries, m.entries[:m.next]...)
+	}
+
+	entries = make([]Entry, 0, len(m.entries))
+	entries = append(entries, m.entries[m.next:]...)
+	return append(entries, m.entries[:m.next]...)
+}
+
+// SetMemoryBuffer will set the number of most recent entries retained in memory, in addition to being written to the
+// file. A size of zero disables the memory buffer (default)
+// Note: Entries are retained once they have been formatted, so entries written to stderr by the stderr fallback are
+// also retained. When the size is changed, the most recent entries which fit within the new size are kept
+func (l *Logger) SetMemoryBuffer(n int) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	if n <= 0 {
+		l.memory = nil
+		return
+	}
+
+	m := newMemoryBuffer(n)
+	if l.memory != nil {
+		entries := l.memory.snapshot()
+		if len(entries) > n {
+			// Only keep the most recent entries
+			entries = entries[len(entries)-n:]
+		}
+
+		for _, e := range entries {
+			m.push(e)
+		}
+	}
+
+	l.memory = m
+	return
+}
+
+// MemoryEntries will return a snapshot of the entries retained in memory, ordered from oldest to newest
+// Note: Returns nil when the memory buffer is not set
+func (l *Logger) MemoryEntries() (entries []Entry) {
+	// Acquire lock
+	l.mu.Lock()
+	m := l.memory
+	// Release lock, the memory buffer has its own lock
+	l.mu.Unlock()
+
+	if m == nil {
+		return
+	}
+
+	return m.snapshot()
+}
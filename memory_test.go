@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestSetMemoryBuffer(t *testing.T) {
+	var (
+		l   *Logger
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if entries := l.MemoryEntries(); entries != nil {
+		t.Fatalf("invalid entries, expected nil and received %v", entries)
+	}
+
+	n := 5
+	if err = l.SetMemoryBuffer(n); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.AddField("service", "api"); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < n+10; i++ {
+		if err = l.Info([]byte(fmt.Sprintf("#%d", i+1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries := l.MemoryEntries()
+	if len(entries) != n {
+		t.Fatalf("invalid number of entries, expected %d and received %d", n, len(entries))
+	}
+
+	for i, e := range entries {
+		expected := fmt.Sprintf("#%d", i+11)
+		if e.Message != expected {
+			t.Fatalf("invalid message, expected \"%s\" and received \"%s\"", expected, e.Message)
+		}
+
+		if e.Level != LevelInfo.name() {
+			t.Fatalf("invalid level, expected \"%s\" and received \"%s\"", LevelInfo.name(), e.Level)
+		}
+
+		if e.Extra["service"] != "api" {
+			t.Fatalf("invalid field, expected \"%s\" and received \"%s\"", "api", e.Extra["service"])
+		}
+
+		if i > 0 && e.Timestamp < entries[i-1].Timestamp {
+			t.Fatalf("invalid timestamp, expected entries to be ordered from oldest to newest")
+		}
+	}
+
+	// Shrinking the buffer keeps the most recent entries
+	if err = l.SetMemoryBuffer(2); err != nil {
+		t.Fatal(err)
+	}
+
+	if entries = l.MemoryEntries(); len(entries) != 2 || entries[0].Message != "#14" || entries[1].Message != "#15" {
+		t.Fatalf("invalid entries, expected \"#14\" and \"#15\" and received %v", entries)
+	}
+
+	if err = l.SetMemoryBuffer(0); err != nil {
+		t.Fatal(err)
+	}
+
+	if entries = l.MemoryEntries(); entries != nil {
+		t.Fatalf("invalid entries, expected nil and received %v", entries)
+	}
+}
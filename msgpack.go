@@ -0,0 +1,400 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// lengthPrefixSize is the size of the big-endian length which precedes each length-prefixed entry
+const lengthPrefixSize = 4
+
+// appendMessagePackEntry will append the entry as a length-prefixed MessagePack map to the provided buffer
+// Note: The map contains the same keys as the JSON format (ts, level, hostname, pid, caller, msg, stack, followed by
+// fields and attributes). Attribute values which are not strings are stored as their raw JSON
+func appendMessagePackEntry(buf []byte, e entry) (out []byte, err error) {
+	var attrs map[string]string
+	if attrs, err = decodeAttrs(e.attrs); err != nil {
+		return
+	}
+
+	// Reserve the length prefix, this is set once the entry has been encoded
+	start := len(buf)
+	out = append(buf, 0, 0, 0, 0)
+
+	// Core keys which are always present (ts, msg)
+	n := 2
+	for _, value := range []string{e.level.name(), e.hostname, e.caller, string(e.stack)} {
+		if value != "" {
+			n++
+		}
+	}
+
+	if e.pid != 0 {
+		n++
+	}
+
+	n += len(e.fields) + len(attrs)
+	out = appendMessagePackMapHeader(out, n)
+
+	out = appendMessagePackString(out, "ts")
+	out = appendMessagePackInt(out, time.Now().UnixNano())
+	out = appendMessagePackPair(out, "level", e.level.name())
+	out = appendMessagePackPair(out, "hostname", e.hostname)
+	if e.pid != 0 {
+		out = appendMessagePackString(out, "pid")
+		out = appendMessagePackInt(out, int64(e.pid))
+	}
+
+	out = appendMessagePackPair(out, "caller", e.caller)
+	out = appendMessagePackString(out, "msg")
+	out = appendMessagePackString(out, string(e.prefix)+string(e.msg))
+	out = appendMessagePackPair(out, "stack", string(e.stack))
+
+	for _, f := range e.fields {
+		out = appendMessagePackString(out, f.key)
+		out = appendMessagePackString(out, f.value)
+	}
+
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		out = appendMessagePackString(out, key)
+		out = appendMessagePackString(out, attrs[key])
+	}
+
+	binary.BigEndian.PutUint32(out[start:], uint32(len(out)-start-lengthPrefixSize))
+	return
+}
+
+// decodeAttrs will decode pre-encoded JSON attributes, non-string values are returned as their raw JSON
+func decodeAttrs(attrs []byte) (decoded map[string]string, err error) {
+	if len(attrs) == 0 {
+		return
+	}
+
+	// Attributes are JSON members preceded by a comma, wrap them as an object
+	obj := make([]byte, 0, len(attrs)+1)
+	obj = append(obj, '{')
+	obj = append(obj, attrs[1:]...)
+	obj = append(obj, '}')
+
+	var raw map[string]json.RawMessage
+	if err = json.Unmarshal(obj, &raw); err != nil {
+		return
+	}
+
+	decoded = make(map[string]string, len(raw))
+	for key, value := range raw {
+		str := string(value)
+		if len(value) > 0 && value[0] == '"' {
+			if err = json.Unmarshal(value, &str); err != nil {
+				return
+			}
+		}
+
+		decoded[key] = str
+	}
+
+	return
+}
+
+// appendMessagePackPair will append the key and string value to the provided buffer, empty values are omitted
+func appendMessagePackPair(buf []byte, key, value string) []byte {
+	if value == "" {
+		return buf
+	}
+
+	buf = appendMessagePackString(buf, key)
+	return appendMessagePackString(buf, value)
+}
+
+// appendMessagePackMapHeader will append a MessagePack map header for n pairs to the provided buffer
+func appendMessagePackMapHeader(buf []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(buf, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return append(buf, 0xde, byte(n>>8), byte(n))
+	default:
+		return append(buf, 0xdf, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+// appendMessagePackString will append a MessagePack string to the provided buffer
+func appendMessagePackString(buf []byte, str string) []byte {
+	n := len(str)
+	switch {
+	case n < 32:
+		buf = append(buf, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		buf = append(buf, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		buf = append(buf, 0xda, byte(n>>8), byte(n))
+	default:
+		buf = append(buf, 0xdb, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+
+	return append(buf, str...)
+}
+
+// appendMessagePackInt will append a MessagePack integer to the provided buffer
+func appendMessagePackInt(buf []byte, n int64) []byte {
+	if n >= 0 && n < 128 {
+		// Positive fixint
+		return append(buf, byte(n))
+	}
+
+	buf = append(buf, 0xd3, 0, 0, 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint64(buf[len(buf)-8:], uint64(n))
+	return buf
+}
+
+// isMessagePackMap will return whether or not the provided byte begins a MessagePack map
+func isMessagePackMap(b byte) bool {
+	return b&0xf0 == 0x80 || b == 0xde || b == 0xdf
+}
+
+// parseMessagePackEntry will parse a MessagePack map into an Entry
+// Note: Unrecognized keys are stored within Extra
+func parseMessagePackEntry(bs []byte) (e Entry, err error) {
+	d := messagePackDecoder{bs: bs}
+
+	var n int
+	if n, err = d.mapHeader(); err != nil {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		var key, value string
+		if key, err = d.string(); err != nil {
+			return
+		}
+
+		if value, err = d.value(); err != nil {
+			return
+		}
+
+		if err = e.setField(key, value); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// messagePackDecoder decodes the subset of MessagePack written by appendMessagePackEntry
+type messagePackDecoder struct {
+	bs []byte
+}
+
+// next will return the next n bytes
+func (d *messagePackDecoder) next(n int) (bs []byte, err error) {
+	if n < 0 || len(d.bs) < n {
+		return nil, ErrInvalidMessagePack
+	}
+
+	bs = d.bs[:n]
+	d.bs = d.bs[n:]
+	return
+}
+
+// uint will return the next big-endian unsigned integer of n bytes
+func (d *messagePackDecoder) uint(n int) (v uint64, err error) {
+	var bs []byte
+	if bs, err = d.next(n); err != nil {
+		return
+	}
+
+	for _, b := range bs {
+		v = v<<8 | uint64(b)
+	}
+
+	return
+}
+
+// mapHeader will return the number of pairs of the next map
+func (d *messagePackDecoder) mapHeader() (n int, err error) {
+	var bs []byte
+	if bs, err = d.next(1); err != nil {
+		return
+	}
+
+	var v uint64
+	switch b := bs[0]; {
+	case b&0xf0 == 0x80:
+		return int(b & 0x0f), nil
+	case b == 0xde:
+		v, err = d.uint(2)
+	case b == 0xdf:
+		v, err = d.uint(4)
+	default:
+		err = ErrInvalidMessagePack
+	}
+
+	return int(v), err
+}
+
+// string will return the next string
+func (d *messagePackDecoder) string() (str string, err error) {
+	var bs []byte
+	if bs, err = d.next(1); err != nil {
+		return
+	}
+
+	var n uint64
+	switch b := bs[0]; {
+	case b&0xe0 == 0xa0:
+		n = uint64(b & 0x1f)
+	case b == 0xd9:
+		n, err = d.uint(1)
+	case b == 0xda:
+		n, err = d.uint(2)
+	case b == 0xdb:
+		n, err = d.uint(4)
+	default:
+		err = ErrInvalidMessagePack
+	}
+
+	if err != nil {
+		return
+	}
+
+	if bs, err = d.next(int(n)); err != nil {
+		return
+	}
+
+	return string(bs), nil
+}
+
+// value will return the next string or integer value as a string
+func (d *messagePackDecoder) value() (value string, err error) {
+	if len(d.bs) == 0 {
+		return "", ErrInvalidMessagePack
+	}
+
+	var n uint64
+	switch b := d.bs[0]; {
+	case b&0xe0 == 0xa0, b == 0xd9, b == 0xda, b == 0xdb:
+		return d.string()
+	case b < 0x80:
+		// Positive fixint
+		d.bs = d.bs[1:]
+		return strconv.Itoa(int(b)), nil
+	case b >= 0xe0:
+		// Negative fixint
+		d.bs = d.bs[1:]
+		return strconv.Itoa(int(int8(b))), nil
+	case b >= 0xcc && b <= 0xcf:
+		// Unsigned integer of 1, 2, 4 or 8 bytes
+		d.bs = d.bs[1:]
+		if n, err = d.uint(1 << (b - 0xcc)); err != nil {
+			return
+		}
+
+		return strconv.FormatUint(n, 10), nil
+	case b >= 0xd0 && b <= 0xd3:
+		// Signed integer of 1, 2, 4 or 8 bytes
+		size := 1 << (b - 0xd0)
+		d.bs = d.bs[1:]
+		if n, err = d.uint(size); err != nil {
+			return
+		}
+
+		// Sign extend the integer from its encoded size
+		shift := uint(64 - size*8)
+		return strconv.FormatInt(int64(n<<shift)>>shift, 10), nil
+	default:
+		return "", ErrInvalidMessagePack
+	}
+}
+
+// parseLengthPrefixed will parse a length-prefixed entry and return it's timestamp and message
+func parseLengthPrefixed(bs []byte) (ts time.Time, msg []byte, err error) {
+	var e Entry
+	if e, err = parseEntry(bs); err != nil {
+		return
+	}
+
+	ts = time.Unix(0, e.Timestamp)
+	msg = []byte(e.Message)
+	return
+}
+
+// splitLengthPrefixed is a bufio.SplitFunc which splits length-prefixed entries
+func splitLengthPrefixed(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if len(data) >= lengthPrefixSize {
+		n := int(binary.BigEndian.Uint32(data))
+		if end := lengthPrefixSize + n; len(data) >= end {
+			return end, data[lengthPrefixSize:end], nil
+		}
+	}
+
+	if atEOF && len(data) > 0 {
+		// File ends with an incomplete entry
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+
+	// Request more data
+	return 0, nil, nil
+}
+
+// isLengthPrefixed will return whether or not the provided file contains length-prefixed entries (E.g. MessagePack)
+// Note: Text formats always begin with a printable character, while the length prefix of an entry smaller than 16MiB
+// always begins with a zero byte
+func isLengthPrefixed(f *os.File) (lengthPrefixed bool, err error) {
+	b := make([]byte, 1)
+	if _, err = f.ReadAt(b, 0); err == io.EOF {
+		// File is empty
+		return false, nil
+	} else if err != nil {
+		return
+	}
+
+	return b[0] == 0, nil
+}
+
+// newScanner will return a scanner for the provided file which splits entries by line, or by length prefix for
+// binary formats
+func newScanner(f *os.File) (s *bufio.Scanner, err error) {
+	var lengthPrefixed bool
+	if lengthPrefixed, err = isLengthPrefixed(f); err != nil {
+		return
+	}
+
+	s = bufio.NewScanner(f)
+	if lengthPrefixed {
+		s.Split(splitLengthPrefixed)
+	}
+
+	return
+}
+
+// UseMessagePack will set whether or not entries are written as length-prefixed MessagePack maps
+// Note: Each entry is preceded by its length as a 4-byte big-endian integer rather than followed by a newline. Entries
+// contain the same keys as the JSON format and are typically 20-30% smaller. Reader detects length-prefixed files
+// automatically, while line-based tools (E.g. Tail, Query and the write-ahead log) only support the text formats
+func (l *Logger) UseMessagePack(useMessagePack bool) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	if useMessagePack {
+		// Set format to MessagePack
+		l.format = formatMessagePack
+	} else if l.format == formatMessagePack {
+		// MessagePack is being disabled, revert to the default format
+		l.format = formatText
+	}
+}
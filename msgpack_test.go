@@ -0,0 +1,108 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestUseMessagePack(t *testing.T) {
+	var err error
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	var jsonFilename, msgpackFilename string
+	if jsonFilename, err = testWriteFormat(testName+"_json", WithJSON(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	if msgpackFilename, err = testWriteFormat(testName+"_msgpack", WithMessagePack(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	var jsonInfo, msgpackInfo os.FileInfo
+	if jsonInfo, err = os.Stat(jsonFilename); err != nil {
+		t.Fatal(err)
+	}
+
+	if msgpackInfo, err = os.Stat(msgpackFilename); err != nil {
+		t.Fatal(err)
+	}
+
+	if msgpackInfo.Size() >= jsonInfo.Size() {
+		t.Fatalf("invalid file size, expected less than %d and received %d", jsonInfo.Size(), msgpackInfo.Size())
+	}
+
+	var r *Reader
+	if r, err = NewReader(msgpackFilename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var count int
+	for {
+		var e Entry
+		if e, err = r.Next(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		count++
+		if expected := fmt.Sprintf("#%d", count); e.Message != expected {
+			t.Fatalf("invalid message, expected %s and received %s", expected, e.Message)
+		}
+
+		if e.Level != LevelInfo.name() {
+			t.Fatalf("invalid level, expected %s and received %s", LevelInfo.name(), e.Level)
+		}
+
+		if e.PID != os.Getpid() {
+			t.Fatalf("invalid pid, expected %d and received %d", os.Getpid(), e.PID)
+		}
+
+		if e.Extra["service"] != "api" {
+			t.Fatalf("invalid field, expected %s and received %s", "api", e.Extra["service"])
+		}
+
+		if ts := time.Unix(0, e.Timestamp); time.Since(ts) > time.Minute {
+			t.Fatalf("invalid timestamp, expected a recent timestamp and received %v", ts)
+		}
+	}
+
+	if count != 50 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 50, count)
+	}
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) error {
+		if string(log) != "#1" {
+			return fmt.Errorf("invalid message, expected %s and received %s", "#1", log)
+		}
+
+		return Break
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testWriteFormat(name string, opt Option) (filename string, err error) {
+	var l *Logger
+	if l, err = NewWithOptions(testDir, name, opt, WithIncludePID(true), WithField("service", "api")); err != nil {
+		return
+	}
+
+	for i := 0; i < 50; i++ {
+		if err = l.Info([]byte(fmt.Sprintf("#%d", i+1))); err != nil {
+			l.Close()
+			return
+		}
+	}
+
+	filename = l.f.Name()
+	err = l.Close()
+	return
+}
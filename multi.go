@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/hatchify/errors"
+)
+
+// NewMulti will return a new instance of MultiLogger
+func NewMulti(writers ...io.Writer) *MultiLogger {
+	var m MultiLogger
+	m.writers = writers
+	return &m
+}
+
+// MultiLogger will broadcast log messages to multiple destinations
+type MultiLogger struct {
+	writers []io.Writer
+}
+
+// Log will log a message to all destinations
+// Note: A failing destination will not prevent the message from being written to the others
+func (m *MultiLogger) Log(msg []byte) (err error) {
+	// Ensure the message is valid before writing to any destinations
+	if bytes.Index(msg, newline) > -1 {
+		// Log message contains a newline, return
+		return ErrMessageContainsNewline
+	}
+
+	// Append newline so the message is line-terminated for generic writers
+	line := make([]byte, 0, len(msg)+1)
+	line = append(line, msg...)
+	line = append(line, '\n')
+
+	var errs errors.ErrorList
+	for _, w := range m.writers {
+		if w == nil {
+			// Writer is not set, continue
+			continue
+		}
+
+		_, err := w.Write(line)
+		errs.Push(err)
+	}
+
+	return errs.Err()
+}
+
+// LogString will log a string message to all destinations
+func (m *MultiLogger) LogString(msg string) (err error) {
+	// Convert message to bytes and pass to m.Log
+	return m.Log([]byte(msg))
+}
+
+// Close will close all destinations which implement io.Closer
+func (m *MultiLogger) Close() (err error) {
+	var errs errors.ErrorList
+	for _, w := range m.writers {
+		c, ok := w.(io.Closer)
+		if !ok {
+			// Writer cannot be closed, continue
+			continue
+		}
+
+		errs.Push(c.Close())
+	}
+
+	return errs.Err()
+}
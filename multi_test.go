@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/hatchify/errors"
+)
+
+func TestMultiLogger(t *testing.T) {
+	var (
+		l   *Logger
+		buf bytes.Buffer
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMulti(l, nil, &buf, failingWriter{})
+	if err = m.LogString("#1"); err != errTestWrite {
+		t.Fatalf("invalid error, expected %v and received %v", errTestWrite, err)
+	}
+
+	if str := buf.String(); str != "#1\n" {
+		t.Fatalf("invalid buffer contents, expected \"%s\" and received \"%s\"", "#1\n", str)
+	}
+
+	if l.count != 1 {
+		t.Fatalf("invalid count, expected %d and received %d", 1, l.count)
+	}
+
+	if err = m.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !l.isClosed() {
+		t.Fatal("expected logger to be closed")
+	}
+}
+
+const errTestWrite = errors.Error("test write error")
+
+type failingWriter struct{}
+
+func (f failingWriter) Write(p []byte) (n int, err error) {
+	return 0, errTestWrite
+}
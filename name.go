@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"os"
+
+	"github.com/hatchify/errors"
+)
+
+// applyName will apply the pending name (if set), this is called once the current file has been closed so the next
+// file is opened with the new name
+// Note: This function expects the lock to be held by the caller, and the buffer to have been flushed
+func (l *Logger) applyName() (err error) {
+	if l.pendingName == "" {
+		// Name has not changed, return
+		return
+	}
+
+	if l.latestSymlink {
+		// Remove the latest symlink of the previous name, the symlink of the new name is created with the next file
+		l.removeLatest()
+	}
+
+	walEnabled := l.wal != nil
+	if walEnabled {
+		// Remove the write-ahead log of the previous name, all entries have been flushed
+		if err = l.closeWAL(); err != nil {
+			return
+		}
+	}
+
+	l.name = l.pendingName
+	l.publicName.Store(l.name)
+	l.pendingName = ""
+
+	if walEnabled {
+		// Open the write-ahead log of the new name
+		l.wal, err = os.OpenFile(l.getWALFilename(), walFlag, l.filePerm)
+	}
+
+	return
+}
+
+// SetName will set the name of the logger, rotating so the next file is created with the new name
+// Note: Existing files (including the file being closed) retain the previous name, and are no longer returned by Files
+// or removed by the retention policy. Loggers which rotate in place (see RotateModeTruncate) keep their current file.
+// The name a logger was registered with (see Register) is unchanged
+func (l *Logger) SetName(name string) (err error) {
+	if len(name) == 0 {
+		return ErrInvalidName
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	if name == l.name {
+		// Name is unchanged, return
+		return
+	}
+
+	l.pendingName = name
+	if l.discard || l.rotateMode == RotateModeTruncate {
+		// Files are not replaced on rotation, apply the name directly
+		if err = l.flush(); err != nil {
+			return
+		}
+
+		return l.applyName()
+	}
+
+	return l.setFile(RotationReasonRename)
+}
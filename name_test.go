@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetName(t *testing.T) {
+	var (
+		l   *Logger
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, "worker"); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.SetName(""); err != ErrInvalidName {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidName, err)
+	}
+
+	if err = l.SetName("leader"); err != nil {
+		t.Fatal(err)
+	}
+
+	if name := l.Name(); name != "leader" {
+		t.Fatalf("invalid name, expected %s and received %s", "leader", name)
+	}
+
+	for i := 5; i < 10; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"worker", "leader"} {
+		var matches []string
+		if matches, err = filepath.Glob(filepath.Join(testDir, name+".*.log")); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(matches) != 1 {
+			t.Fatalf("invalid number of %s files, expected %d and received %d", name, 1, len(matches))
+		}
+
+		var count int
+		if count, err = testCountLines(matches[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		if count != 5 {
+			t.Fatalf("invalid number of %s lines, expected %d and received %d", name, 5, count)
+		}
+	}
+}
+
+func testCountLines(filename string) (count int, err error) {
+	var bs []byte
+	if bs, err = ioutil.ReadFile(filename); err != nil {
+		return
+	}
+
+	return bytes.Count(bs, newline), nil
+}
@@ -0,0 +1,458 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// Option is used to configure a Logger during construction, see NewWithOptions
+type Option func(*Logger) error
+
+// WithNumLines will return an option which calls SetNumLines
+func WithNumLines(n int) Option {
+	return func(l *Logger) error {
+		l.SetNumLines(n)
+		return nil
+	}
+}
+
+// WithMaxBytes will return an option which calls SetMaxBytes
+func WithMaxBytes(n int64) Option {
+	return func(l *Logger) error {
+		l.SetMaxBytes(n)
+		return nil
+	}
+}
+
+// WithBufferSize will return an option which calls SetBufferSize
+func WithBufferSize(bytes int) Option {
+	return func(l *Logger) error {
+		return l.SetBufferSize(bytes)
+	}
+}
+
+// WithSeparator will return an option which calls SetSeparator
+func WithSeparator(sep byte) Option {
+	return func(l *Logger) error {
+		return l.SetSeparator(sep)
+	}
+}
+
+// WithEscapeNewlines will return an option which calls SetEscapeNewlines
+func WithEscapeNewlines(escape bool) Option {
+	return func(l *Logger) error {
+		l.SetEscapeNewlines(escape)
+		return nil
+	}
+}
+
+// WithPrefix will return an option which calls SetPrefix
+func WithPrefix(prefix []byte) Option {
+	return func(l *Logger) error {
+		return l.SetPrefix(prefix)
+	}
+}
+
+// WithName will return an option which calls SetName
+func WithName(name string) Option {
+	return func(l *Logger) error {
+		return l.SetName(name)
+	}
+}
+
+// WithLevel will return an option which calls SetLevel
+func WithLevel(level Level) Option {
+	return func(l *Logger) error {
+		l.SetLevel(level)
+		return nil
+	}
+}
+
+// WithJSON will return an option which calls UseJSON
+func WithJSON(useJSON bool) Option {
+	return func(l *Logger) error {
+		l.UseJSON(useJSON)
+		return nil
+	}
+}
+
+// WithLogfmt will return an option which calls UseLogfmt
+func WithLogfmt(useLogfmt bool) Option {
+	return func(l *Logger) error {
+		l.UseLogfmt(useLogfmt)
+		return nil
+	}
+}
+
+// WithCSV will return an option which calls UseCSV
+func WithCSV(useCSV bool) Option {
+	return func(l *Logger) error {
+		return l.UseCSV(useCSV)
+	}
+}
+
+// WithField will return an option which calls AddField
+func WithField(key, value string) Option {
+	return func(l *Logger) error {
+		return l.AddField(key, value)
+	}
+}
+
+// WithContextExtractor will return an option which calls AddContextExtractor
+func WithContextExtractor(key interface{}, fieldName string) Option {
+	return func(l *Logger) error {
+		return l.AddContextExtractor(key, fieldName)
+	}
+}
+
+// WithRotateInterval will return an option which calls SetRotateInterval
+func WithRotateInterval(duration time.Duration) Option {
+	return func(l *Logger) error {
+		return l.SetRotateInterval(duration)
+	}
+}
+
+// WithRotateAtMidnight will return an option which calls SetRotateAtMidnight
+func WithRotateAtMidnight(rotateAtMidnight bool) Option {
+	return func(l *Logger) error {
+		return l.SetRotateAtMidnight(rotateAtMidnight)
+	}
+}
+
+// WithRotateMode will return an option which calls SetRotateMode
+func WithRotateMode(mode RotateMode) Option {
+	return func(l *Logger) error {
+		return l.SetRotateMode(mode)
+	}
+}
+
+// WithRotateFn will return an option which calls SetRotateFn
+func WithRotateFn(fn RotateFn) Option {
+	return func(l *Logger) error {
+		l.SetRotateFn(fn)
+		return nil
+	}
+}
+
+// WithRotationHook will return an option which calls SetRotationHook
+func WithRotationHook(fn RotationHook) Option {
+	return func(l *Logger) error {
+		l.SetRotationHook(fn)
+		return nil
+	}
+}
+
+// WithCompressOnRotate will return an option which calls SetCompressOnRotate
+func WithCompressOnRotate(compress bool) Option {
+	return func(l *Logger) error {
+		l.SetCompressOnRotate(compress)
+		return nil
+	}
+}
+
+// WithIntegrityCheck will return an option which calls SetIntegrityCheck
+func WithIntegrityCheck(integrity bool) Option {
+	return func(l *Logger) error {
+		return l.SetIntegrityCheck(integrity)
+	}
+}
+
+// WithRetainCount will return an option which calls SetRetainCount
+func WithRetainCount(n int) Option {
+	return func(l *Logger) error {
+		l.SetRetainCount(n)
+		return nil
+	}
+}
+
+// WithRetainDuration will return an option which calls SetRetainDuration
+func WithRetainDuration(duration time.Duration) Option {
+	return func(l *Logger) error {
+		l.SetRetainDuration(duration)
+		return nil
+	}
+}
+
+// WithMaxDiskUsage will return an option which calls SetMaxDiskUsage
+func WithMaxDiskUsage(bytes int64) Option {
+	return func(l *Logger) error {
+		l.SetMaxDiskUsage(bytes)
+		return nil
+	}
+}
+
+// WithErrorHandler will return an option which calls SetErrorHandler
+func WithErrorHandler(fn ErrorFn) Option {
+	return func(l *Logger) error {
+		l.SetErrorHandler(fn)
+		return nil
+	}
+}
+
+// WithIncludeHostname will return an option which calls SetIncludeHostname
+func WithIncludeHostname(include bool) Option {
+	return func(l *Logger) error {
+		return l.SetIncludeHostname(include)
+	}
+}
+
+// WithIncludePID will return an option which calls SetIncludePID
+func WithIncludePID(include bool) Option {
+	return func(l *Logger) error {
+		l.SetIncludePID(include)
+		return nil
+	}
+}
+
+// WithCallerDepth will return an option which calls SetCallerDepth
+func WithCallerDepth(depth int) Option {
+	return func(l *Logger) error {
+		l.SetCallerDepth(depth)
+		return nil
+	}
+}
+
+// WithCallerFullPath will return an option which calls SetCallerFullPath
+func WithCallerFullPath(fullPath bool) Option {
+	return func(l *Logger) error {
+		l.SetCallerFullPath(fullPath)
+		return nil
+	}
+}
+
+// WithCaptureStack will return an option which calls SetCaptureStack
+func WithCaptureStack(levels ...Level) Option {
+	return func(l *Logger) error {
+		l.SetCaptureStack(levels...)
+		return nil
+	}
+}
+
+// WithStackDelimiter will return an option which calls SetStackDelimiter
+func WithStackDelimiter(delim []byte) Option {
+	return func(l *Logger) error {
+		return l.SetStackDelimiter(delim)
+	}
+}
+
+// WithTimestampFormat will return an option which calls SetTimestampFormat
+func WithTimestampFormat(format string) Option {
+	return func(l *Logger) error {
+		l.SetTimestampFormat(format)
+		return nil
+	}
+}
+
+// WithTimestampFunc will return an option which calls SetTimestampFunc
+func WithTimestampFunc(fn TimestampFn) Option {
+	return func(l *Logger) error {
+		l.SetTimestampFunc(fn)
+		return nil
+	}
+}
+
+// WithFlushInterval will return an option which calls SetFlushInterval
+func WithFlushInterval(interval time.Duration) Option {
+	return func(l *Logger) error {
+		return l.SetFlushInterval(interval)
+	}
+}
+
+// WithLatestSymlink will return an option which calls SetLatestSymlink
+func WithLatestSymlink(latestSymlink bool) Option {
+	return func(l *Logger) error {
+		return l.SetLatestSymlink(latestSymlink)
+	}
+}
+
+// WithSignal will return an option which calls ListenForSignal
+func WithSignal(sig os.Signal) Option {
+	return func(l *Logger) error {
+		return l.ListenForSignal(sig)
+	}
+}
+
+// WithTee will return an option which calls SetTee
+func WithTee(w io.Writer) Option {
+	return func(l *Logger) error {
+		return l.SetTee(w)
+	}
+}
+
+// WithRateLimit will return an option which calls SetRateLimit
+func WithRateLimit(maxPerWindow int, window time.Duration) Option {
+	return func(l *Logger) error {
+		return l.SetRateLimit(maxPerWindow, window)
+	}
+}
+
+// WithDedup will return an option which calls SetDedup
+func WithDedup(window time.Duration) Option {
+	return func(l *Logger) error {
+		return l.SetDedup(window)
+	}
+}
+
+// WithAtomicSwap will return an option which calls SetAtomicSwap
+func WithAtomicSwap(atomicSwap bool) Option {
+	return func(l *Logger) error {
+		return l.SetAtomicSwap(atomicSwap)
+	}
+}
+
+// WithFileLock will return an option which calls SetFileLock
+func WithFileLock(fileLock bool) Option {
+	return func(l *Logger) error {
+		return l.SetFileLock(fileLock)
+	}
+}
+
+// WithWAL will return an option which calls SetWALEnabled
+func WithWAL(enabled bool) Option {
+	return func(l *Logger) error {
+		return l.SetWALEnabled(enabled)
+	}
+}
+
+// WithAsync will return an option which calls SetAsync
+func WithAsync(queueDepth int) Option {
+	return func(l *Logger) error {
+		return l.SetAsync(queueDepth)
+	}
+}
+
+// WithSampleRate will return an option which calls SetSampleRate
+func WithSampleRate(n int) Option {
+	return func(l *Logger) error {
+		return l.SetSampleRate(n)
+	}
+}
+
+// WithHook will return an option which calls AddHook
+func WithHook(h Hook) Option {
+	return func(l *Logger) error {
+		return l.AddHook(h)
+	}
+}
+
+// WithManifest will return an option which calls SetManifestEnabled
+func WithManifest(enabled bool) Option {
+	return func(l *Logger) error {
+		return l.SetManifestEnabled(enabled)
+	}
+}
+
+// WithMaxLineLength will return an option which calls SetMaxLineLength
+func WithMaxLineLength(n int) Option {
+	return func(l *Logger) error {
+		l.SetMaxLineLength(n)
+		return nil
+	}
+}
+
+// WithTruncationMarker will return an option which calls SetTruncationMarker
+func WithTruncationMarker(marker []byte) Option {
+	return func(l *Logger) error {
+		return l.SetTruncationMarker(marker)
+	}
+}
+
+// WithDirPermissions will return an option which calls SetDirPermissions
+func WithDirPermissions(mode os.FileMode) Option {
+	return func(l *Logger) error {
+		return l.SetDirPermissions(mode)
+	}
+}
+
+// WithFilePermissions will return an option which calls SetFilePermissions
+// Note: Options are applied after the initial file is created, so the initial file's mode is updated in place
+func WithFilePermissions(mode os.FileMode) Option {
+	return func(l *Logger) (err error) {
+		if err = l.SetFilePermissions(mode); err != nil || l.f == nil {
+			return
+		}
+
+		return l.f.Chmod(mode)
+	}
+}
+
+// WithMinFreeDisk will return an option which calls SetMinFreeDisk
+func WithMinFreeDisk(bytes int64) Option {
+	return func(l *Logger) error {
+		l.SetMinFreeDisk(bytes)
+		return nil
+	}
+}
+
+// WithStderrFallback will return an option which calls SetStderrFallback
+func WithStderrFallback(fallback bool) Option {
+	return func(l *Logger) error {
+		return l.SetStderrFallback(fallback)
+	}
+}
+
+// WithMemoryBuffer will return an option which calls SetMemoryBuffer
+func WithMemoryBuffer(n int) Option {
+	return func(l *Logger) error {
+		return l.SetMemoryBuffer(n)
+	}
+}
+
+// WithBase64Messages will return an option which calls SetBase64Messages
+func WithBase64Messages(encode bool) Option {
+	return func(l *Logger) error {
+		l.SetBase64Messages(encode)
+		return nil
+	}
+}
+
+// WithWriteTimeout will return an option which calls SetWriteTimeout
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(l *Logger) error {
+		return l.SetWriteTimeout(timeout)
+	}
+}
+
+// WithHashInFilename will return an option which calls SetHashInFilename
+func WithHashInFilename(hashInFilename bool) Option {
+	return func(l *Logger) error {
+		return l.SetHashInFilename(hashInFilename)
+	}
+}
+
+// WithMessagePack will return an option which calls UseMessagePack
+func WithMessagePack(useMessagePack bool) Option {
+	return func(l *Logger) error {
+		l.UseMessagePack(useMessagePack)
+		return nil
+	}
+}
+
+// WithHMACKey will return an option which calls SetHMACKey
+func WithHMACKey(key []byte) Option {
+	return func(l *Logger) error {
+		return l.SetHMACKey(key)
+	}
+}
+
+// WithCompactMode will return an option which calls SetCompactMode
+func WithCompactMode(compact bool) Option {
+	return func(l *Logger) error {
+		return l.SetCompactMode(compact)
+	}
+}
+
+// WithEncryptionKey will return an option which calls SetEncryptionKey
+func WithEncryptionKey(key []byte) Option {
+	return func(l *Logger) error {
+		return l.SetEncryptionKey(key)
+	}
+}
+
+// WithRotateHourly will return an option which calls SetRotateHourly
+func WithRotateHourly(rotateHourly bool) Option {
+	return func(l *Logger) error {
+		return l.SetRotateHourly(rotateHourly)
+	}
+}
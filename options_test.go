@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = NewWithOptions(testDir, testName, WithNumLines(2), WithLevel(LevelWarn), WithSeparator('|')); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if l.numLines != 2 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 2, l.numLines)
+	}
+
+	if l.level != LevelWarn {
+		t.Fatalf("invalid level, expected %d and received %d", LevelWarn, l.level)
+	}
+
+	if l.sep != '|' {
+		t.Fatalf("invalid separator, expected %q and received %q", '|', l.sep)
+	}
+}
+
+func TestNewWithOptions_error(t *testing.T) {
+	var (
+		files []logFile
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if _, err = NewWithOptions(testDir, testName, WithNumLines(2), WithBufferSize(0)); err != ErrInvalidBufferSize {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidBufferSize, err)
+	}
+
+	if files, err = listFiles(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 0 {
+		t.Fatalf("invalid number of files, expected %d and received %d", 0, len(files))
+	}
+}
@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"os"
+
+	"github.com/hatchify/errors"
+)
+
+// defaultFilePermissions is the default mode used when creating log files
+const defaultFilePermissions os.FileMode = 0644
+
+// SetFilePermissions will set the mode used when creating log files (defaults to 0644)
+// Note: The mode applies to files created after it is set, the current file keeps its mode until the next rotation.
+// The write-ahead log is created with the same mode, and compressed files inherit the mode of the file they replace
+func (l *Logger) SetFilePermissions(mode os.FileMode) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.filePerm = mode
+	return
+}
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestSetFilePermissions(t *testing.T) {
+	var (
+		l   *Logger
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetFilePermissions(0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Current file keeps its mode until the next rotation
+	if err = testFileMode(l.f.Name(), defaultFilePermissions); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("hello world"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testFileMode(l.f.Name(), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithFilePermissions(t *testing.T) {
+	var (
+		l   *Logger
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = NewWithOptions(testDir, testName, WithFilePermissions(0640)); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = testFileMode(l.f.Name(), 0640); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testFileMode(filename string, expected os.FileMode) (err error) {
+	var info os.FileInfo
+	if info, err = os.Stat(filename); err != nil {
+		return
+	}
+
+	if mode := info.Mode().Perm(); mode != expected {
+		return fmt.Errorf("invalid mode, expected %v and received %v", expected, mode)
+	}
+
+	return
+}
@@ -0,0 +1,41 @@
+package logger
+
+import "os"
+
+// SetIncludeHostname will set whether or not the hostname is included within entries
+// Note: The hostname is resolved once when enabled. Within text entries, the hostname follows the level (if set) and
+// precedes the process ID (if set), E.g. timestamp@level@hostname@pid@message
+func (l *Logger) SetIncludeHostname(include bool) (err error) {
+	var hostname string
+	if include {
+		// Resolve hostname before acquiring lock
+		if hostname, err = os.Hostname(); err != nil {
+			return
+		}
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set hostname, an empty hostname disables inclusion
+	l.hostname = hostname
+	return
+}
+
+// SetIncludePID will set whether or not the process ID is included within entries
+// Note: Within text entries, the process ID follows the hostname (if set) and precedes the caller (if set), E.g.
+// timestamp@level@hostname@pid@message
+func (l *Logger) SetIncludePID(include bool) {
+	var pid int
+	if include {
+		pid = os.Getpid()
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set process ID, a zero process ID disables inclusion
+	l.pid = pid
+}
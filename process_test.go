@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestIncludeHostnameAndPID(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		hostname string
+		logs     []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if hostname, err = os.Hostname(); err != nil {
+		t.Fatal(err)
+	}
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetIncludeHostname(true); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetIncludePID(true)
+	if err = l.Info([]byte("#1")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetIncludeHostname(false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#2"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		fmt.Sprintf("INFO @%s@%d@#1", hostname, os.Getpid()),
+		fmt.Sprintf("%d@#2", os.Getpid()),
+	}
+
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
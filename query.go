@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+// Query will return the entries of all log files with timestamps between from and to (inclusive), sorted by
+// timestamp (oldest first)
+// Note: Files are read line by line, so only matching entries are held in memory. Files which fail to parse are
+// skipped and their errors are returned as an *errors.ErrorList alongside the entries which were parsed
+func (l *Logger) Query(from, to time.Time) (entries []Entry, err error) {
+	// Acquire lock
+	l.mu.Lock()
+	if !l.isClosed() {
+		// Flush buffered entries so they are visible to the query
+		err = l.flush()
+	}
+	// Release lock, the files are read without holding the lock
+	l.mu.Unlock()
+
+	if err != nil {
+		return
+	}
+
+	var filenames []string
+	if filenames, err = l.Files(); err != nil {
+		return
+	}
+
+	name := l.Name()
+
+	var errs errors.ErrorList
+	for i, filename := range filenames {
+		start, ok := parseFilename(path.Base(filename), name)
+		if !ok {
+			continue
+		}
+
+		if start.After(to) {
+			// File (and every file after it) was created after the end of the range, break
+			break
+		}
+
+		if i < len(filenames)-1 {
+			// A file ends when the following file is created
+			if end, ok := parseFilename(path.Base(filenames[i+1]), name); ok && end.Before(from) {
+				// File ended before the start of the range, continue
+				continue
+			}
+		}
+
+		if entries, err = queryFile(filename, from.UnixNano(), to.UnixNano(), entries); err != nil {
+			errs.Push(fmt.Errorf("error querying \"%s\": %v", filename, err))
+		}
+	}
+
+	// Entries are sorted by file, ensure order is maintained for entries which span files
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp < entries[j].Timestamp
+	})
+
+	err = errs.Err()
+	return
+}
+
+// queryFile will append the entries of a log file with timestamps between from and to (inclusive)
+func queryFile(filename string, from, to int64, entries []Entry) (out []Entry, err error) {
+	out = entries
+
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(filename, compressedExt) {
+		var gz *gzip.Reader
+		if gz, err = gzip.NewReader(f); err != nil {
+			return
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		var e Entry
+		if e, err = parseEntry(s.Bytes()); err != nil {
+			return
+		}
+
+		if e.Timestamp < from || e.Timestamp > to {
+			continue
+		}
+
+		out = append(out, e)
+	}
+
+	err = s.Err()
+	return
+}
@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	var (
+		l *Logger
+
+		entries []Entry
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	l.SetCompressOnRotate(true)
+
+	// Write three batches of entries to three separate files, recording the boundaries of the middle batch
+	var (
+		from, to time.Time
+		rotated  []string
+	)
+
+	for batch := 0; batch < 3; batch++ {
+		if batch == 1 {
+			from = time.Now()
+		}
+
+		for i := 0; i < 10; i++ {
+			if err = l.LogString(fmt.Sprintf("#%d", batch*10+i+1)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if batch == 1 {
+			to = time.Now()
+		}
+
+		time.Sleep(time.Millisecond * 10)
+
+		if batch < 2 {
+			rotated = append(rotated, l.f.Name())
+			if err = l.Rotate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Wait for the rotated files to be replaced by their compressed files
+	for _, filename := range rotated {
+		if err = waitForRemoval(filename); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if entries, err = l.Query(from, to); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 10 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 10, len(entries))
+	}
+
+	for i, e := range entries {
+		expected := fmt.Sprintf("#%d", i+11)
+		if e.Message != expected {
+			t.Fatalf("invalid message, expected \"%s\" and received \"%s\"", expected, e.Message)
+		}
+	}
+
+	if entries, err = l.Query(time.Time{}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 30 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 30, len(entries))
+	}
+
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Timestamp < entries[i-1].Timestamp {
+			t.Fatalf("invalid order, entry %d is before entry %d", i, i-1)
+		}
+	}
+
+	current := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait for the current file to be compressed on close
+	if err = waitForRemoval(current); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQuery_error(t *testing.T) {
+	var (
+		l *Logger
+
+		entries []Entry
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	// Write a malformed log file which precedes the logger's file
+	filename := path.Join(testDir, fmt.Sprintf("%s.%d.log", testName, time.Now().Add(-time.Hour).UnixNano()))
+	if err = ioutil.WriteFile(filename, []byte("malformed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.LogString("hello world"); err != nil {
+		t.Fatal(err)
+	}
+
+	if entries, err = l.Query(time.Time{}, time.Now()); err == nil {
+		t.Fatal("expected error for malformed file")
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 1, len(entries))
+	}
+}
@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+// newRateLimiter will return a new rate limiter
+func newRateLimiter(l *Logger, maxPerWindow int, window time.Duration) *rateLimiter {
+	var r rateLimiter
+	r.l = l
+	r.max = int64(maxPerWindow)
+	r.window = window
+	return &r
+}
+
+// rateLimiter suppresses repeated identical messages within a time window
+type rateLimiter struct {
+	l *Logger
+
+	// Maximum number of identical messages per window
+	max int64
+	// Duration of each window, starting from the first occurrence of a message
+	window time.Duration
+
+	// Occurrence counts by message, values are *int64
+	counts sync.Map
+}
+
+// allow will return whether or not the message is within the rate limit
+// Note: The window for a message starts with its first occurrence, the count is reset when the window expires
+func (r *rateLimiter) allow(msg []byte) bool {
+	key := string(msg)
+	v, loaded := r.counts.LoadOrStore(key, new(int64))
+	if !loaded {
+		// First occurrence of this message, expire the window once it has elapsed
+		time.AfterFunc(r.window, func() { r.expire(key) })
+	}
+
+	return atomic.AddInt64(v.(*int64), 1) <= r.max
+}
+
+// expire will reset the count for a message and write a suppression notice if messages were dropped
+func (r *rateLimiter) expire(key string) {
+	l := r.l
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.unlock()
+
+	v, ok := r.counts.Load(key)
+	if !ok {
+		return
+	}
+
+	r.counts.Delete(key)
+
+	suppressed := atomic.LoadInt64(v.(*int64)) - r.max
+	if suppressed <= 0 || l.isClosed() {
+		// No messages were suppressed or the logger has been closed, return
+		return
+	}
+
+	notice := fmt.Sprintf("[SUPPRESSED: %s repeated %d times in %v]", key, suppressed, r.window)
+	// Write notice directly, bypassing the rate limit
+	if err := l.writeEntry(newEntry(levelNone, []byte(notice))); err != nil {
+		l.addPending(newErrorCall(l.errorHandler(), err))
+	}
+}
+
+// SetRateLimit will set the maximum number of identical messages which are written within a window
+// Note: Messages exceeding the limit are dropped without an error. Once the window for a message expires, a single
+// notice is written stating how many copies were suppressed. A maxPerWindow of zero disables rate limiting
+func (l *Logger) SetRateLimit(maxPerWindow int, window time.Duration) (err error) {
+	if maxPerWindow > 0 && window <= 0 {
+		return ErrInvalidRateLimitWindow
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	if maxPerWindow <= 0 {
+		// Rate limiting is being disabled
+		l.rateLimit = nil
+		return
+	}
+
+	l.rateLimit = newRateLimiter(l, maxPerWindow, window)
+	return
+}
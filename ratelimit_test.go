@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetRateLimit(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetRateLimit(3, 0); err != ErrInvalidRateLimitWindow {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidRateLimitWindow, err)
+	}
+
+	if err = l.SetRateLimit(3, time.Millisecond*50); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if err = l.LogString("connection refused"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Wait for the window to expire and the suppression notice to be written
+	deadline := time.Now().Add(time.Second)
+	for l.Stats().TotalLinesWritten < 4 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logs) != 4 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 4, len(logs))
+	}
+
+	for _, log := range logs[:3] {
+		if log != "connection refused" {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", "connection refused", log)
+		}
+	}
+
+	if !strings.HasPrefix(logs[3], "[SUPPRESSED: connection refused repeated 7 times in") {
+		t.Fatalf("invalid suppression notice: \"%s\"", logs[3])
+	}
+}
@@ -2,6 +2,8 @@ package logger
 
 import (
 	"bufio"
+	"crypto/cipher"
+	"io"
 	"os"
 	"sync"
 	"time"
@@ -9,6 +11,12 @@ import (
 	"github.com/hatchify/errors"
 )
 
+// Open will open a log file for reading
+// Note: This is an alias of NewReader
+func Open(filename string) (rp *Reader, err error) {
+	return NewReader(filename)
+}
+
 // NewReader will return a new reader
 func NewReader(filename string) (rp *Reader, err error) {
 	var f *os.File
@@ -32,6 +40,14 @@ type Reader struct {
 	mu sync.Mutex
 
 	f *os.File
+	// Scanner used by Next, created on the first call
+	s *bufio.Scanner
+	// Key used to verify the HMAC of each entry (set when opened with OpenWithHMACKey)
+	hmacKey []byte
+	// Whether or not lines are raw messages without a timestamp (set when opened with OpenCompact)
+	compact bool
+	// Cipher used to decrypt each entry (set when opened with OpenEncrypted)
+	aead cipher.AEAD
 }
 
 func (r *Reader) forEach(offset int64, fn Handler) (err error) {
@@ -47,18 +63,49 @@ func (r *Reader) forEach(offset int64, fn Handler) (err error) {
 		return
 	}
 
+	var lengthPrefixed bool
+	if !r.compact {
+		// Determine whether or not the file contains length-prefixed entries, compact files are always line-based
+		if lengthPrefixed, err = isLengthPrefixed(r.f); err != nil {
+			return
+		}
+	}
+
 	// Create a new scanner
 	s := bufio.NewScanner(r.f)
+	if lengthPrefixed {
+		// File contains length-prefixed entries (E.g. MessagePack)
+		s.Split(splitLengthPrefixed)
+	}
 
 	var cnt int64
 	for s.Scan() {
 		var (
-			ts  time.Time
-			log []byte
+			ts   time.Time
+			log  []byte
+			line = s.Bytes()
 		)
 
-		// Parse timestamp and log bytes from line
-		if ts, log, err = parseLine(s.Bytes()); err != nil {
+		if r.aead != nil {
+			// Decrypt line
+			if line, err = decryptEntry(r.aead, line); err != nil {
+				return
+			}
+		}
+
+		switch {
+		case r.compact:
+			// Line is the raw message
+			log = line
+		case lengthPrefixed:
+			// Parse timestamp and message from entry
+			ts, log, err = parseLengthPrefixed(line)
+		default:
+			// Parse timestamp and log bytes from line
+			ts, log, err = parseLine(line)
+		}
+
+		if err != nil {
 			return
 		}
 
@@ -89,6 +136,70 @@ func (r *Reader) ForEach(offset int64, fn Handler) (err error) {
 	return
 }
 
+// Next will return the next entry, io.EOF is returned once all entries have been read
+// Note: Malformed lines will return a *ParseError, subsequent calls will continue with the following line
+func (r *Reader) Next() (e Entry, err error) {
+	// Acquire reader lock
+	r.mu.Lock()
+	// Defer the release of the reader lock
+	defer r.mu.Unlock()
+
+	// If our file is nil, this reader has been closed
+	if r.f == nil {
+		// Reader is closed, return
+		err = errors.ErrIsClosed
+		return
+	}
+
+	if r.s == nil && r.compact {
+		// Scanner does not exist, create it (compact files are always line-based)
+		r.s = bufio.NewScanner(r.f)
+	} else if r.s == nil {
+		// Scanner does not exist, create it
+		if r.s, err = newScanner(r.f); err != nil {
+			return
+		}
+	}
+
+	if !r.s.Scan() {
+		if err = r.s.Err(); err == nil {
+			// Scanner has reached the end of the file
+			err = io.EOF
+		}
+
+		return
+	}
+
+	line := r.s.Bytes()
+	if r.aead != nil {
+		// Decrypt line
+		if line, err = decryptEntry(r.aead, line); err != nil {
+			return
+		}
+	}
+
+	if r.compact {
+		// Line is the raw message, decode it when Base64 encoded
+		e.Message = decodeBase64Message(string(line))
+		return
+	}
+
+	if r.hmacKey != nil {
+		// Verify entry and remove its HMAC
+		if line, err = r.verifyHMAC(line); err != nil {
+			return
+		}
+	}
+
+	if e, err = parseEntry(line); err != nil {
+		return
+	}
+
+	// Decode message when Base64 encoded (see SetBase64Messages)
+	e.Message = decodeBase64Message(e.Message)
+	return
+}
+
 // Close will close a reader
 func (r *Reader) Close() (err error) {
 	// Acquire reader lock
@@ -111,3 +222,8 @@ func (r *Reader) Close() (err error) {
 	r.f = nil
 	return
 }
+
+// Unescape will restore the backslashes, newlines and carriage returns of a message written with newline escaping enabled
+func (r *Reader) Unescape(s string) string {
+	return unescapeNewlines(s)
+}
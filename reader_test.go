@@ -2,7 +2,9 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -73,3 +75,200 @@ func TestReader(t *testing.T) {
 	}
 
 }
+
+func TestReaderNext(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+		f *os.File
+
+		entries  []Entry
+		rawLines []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Info([]byte("#1")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#2"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if f, err = os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = f.WriteString("no separator\nabc@bad timestamp\n1@ERROR@#3\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = Open(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for {
+		var e Entry
+		e, err = r.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if perr, ok := err.(*ParseError); ok {
+			rawLines = append(rawLines, perr.RawLine())
+			continue
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	expected := []Entry{
+		{Level: "INFO", Message: "#1"},
+		{Message: "#2"},
+		{Timestamp: 1, Level: "ERROR", Message: "#3"},
+	}
+
+	if len(entries) != len(expected) {
+		t.Fatalf("invalid number of entries, expected %d and received %d", len(expected), len(entries))
+	}
+
+	for i, e := range entries {
+		if e.Timestamp == 0 {
+			t.Fatal("expected timestamp to be set")
+		}
+
+		if i < 2 {
+			expected[i].Timestamp = e.Timestamp
+		}
+
+		if !reflect.DeepEqual(e, expected[i]) {
+			t.Fatalf("invalid entry, expected %+v and received %+v", expected[i], e)
+		}
+	}
+
+	if len(rawLines) != 2 || rawLines[0] != "no separator" || rawLines[1] != "abc@bad timestamp" {
+		t.Fatalf("invalid raw lines: %v", rawLines)
+	}
+}
+
+func TestReaderNext_structured(t *testing.T) {
+	formats := map[string]func(*Logger){
+		"json":   func(l *Logger) { l.UseJSON(true) },
+		"logfmt": func(l *Logger) { l.UseLogfmt(true) },
+	}
+
+	for name, useFormat := range formats {
+		t.Run(name, func(t *testing.T) {
+			testStructuredRoundTrip(t, useFormat)
+		})
+	}
+}
+
+func testStructuredRoundTrip(t *testing.T, useFormat func(*Logger)) {
+	var (
+		l *Logger
+		r *Reader
+
+		expected []Entry
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	useFormat(l)
+	l.SetIncludePID(true)
+	l.SetCallerDepth(1)
+
+	if err = l.SetIncludeHostname(true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.AddField("service", "api server"); err != nil {
+		t.Fatal(err)
+	}
+
+	hostname, _ := os.Hostname()
+	fns := []func([]byte) error{l.Log, l.Debug, l.Info, l.Warn, l.Error}
+	levels := []string{"", "DEBUG", "INFO", "WARN", "ERROR"}
+	for i := 0; i < 100; i++ {
+		msg := fmt.Sprintf("entry #%d with \"quotes\", a=b, \\ and unicode é\t%d", i, i)
+		if err = fns[i%len(fns)]([]byte(msg)); err != nil {
+			t.Fatal(err)
+		}
+
+		expected = append(expected, Entry{
+			Level:    levels[i%len(levels)],
+			Message:  msg,
+			Hostname: hostname,
+			PID:      os.Getpid(),
+			Extra:    map[string]string{"service": "api server"},
+		})
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for i := 0; ; i++ {
+		var e Entry
+		if e, err = r.Next(); err == io.EOF {
+			if i != len(expected) {
+				t.Fatalf("invalid number of entries, expected %d and received %d", len(expected), i)
+			}
+
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		if e.Timestamp == 0 {
+			t.Fatal("expected timestamp to be set")
+		}
+
+		if e.Caller == "" {
+			t.Fatal("expected caller to be set")
+		}
+
+		expected[i].Timestamp = e.Timestamp
+		expected[i].Caller = e.Caller
+		if !reflect.DeepEqual(e, expected[i]) {
+			t.Fatalf("invalid entry, expected %+v and received %+v", expected[i], e)
+		}
+	}
+}
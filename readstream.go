@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"context"
+	"io"
+)
+
+// Stream will open the provided log file and send each parsed entry to the returned entry channel
+// Note: Entries are read within a goroutine which blocks until the consumer receives each entry, so entries are never
+// dropped. Both channels are closed once the file has been read, an error has occurred, or the context is cancelled.
+// At most one error (E.g. a *ParseError for a malformed line, or the context's error) is sent before the channels are
+// closed
+func Stream(ctx context.Context, path string) (<-chan Entry, <-chan error) {
+	entries := make(chan Entry)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(entries)
+		defer close(errs)
+
+		if err := streamEntries(ctx, path, entries); err != nil {
+			errs <- err
+		}
+	}()
+
+	return entries, errs
+}
+
+// streamEntries will send each entry of the provided log file to the entries channel until the file has been read or
+// the context is cancelled
+func streamEntries(ctx context.Context, path string, entries chan<- Entry) (err error) {
+	var r *Reader
+	if r, err = NewReader(path); err != nil {
+		return
+	}
+	defer r.Close()
+
+	for {
+		var e Entry
+		if e, err = r.Next(); err == io.EOF {
+			// All entries have been read, return
+			return nil
+		} else if err != nil {
+			return
+		}
+
+		select {
+		case entries <- e:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	var err error
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	filenames := make([]string, 10)
+	for i := range filenames {
+		var l *Logger
+		if l, err = New(testDir, fmt.Sprintf("%s_%d", testName, i)); err != nil {
+			t.Fatal(err)
+		}
+
+		for j := 0; j < 1000; j++ {
+			if err = l.Info([]byte(fmt.Sprintf("#%d", j+1))); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		filenames[i] = l.f.Name()
+		if err = l.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	results := make([]error, len(filenames))
+	for i, filename := range filenames {
+		wg.Add(1)
+		go func(i int, filename string) {
+			defer wg.Done()
+			results[i] = testStream(filename, 1000)
+		}(i, filename)
+	}
+
+	wg.Wait()
+
+	for _, err = range results {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestStream_cancel(t *testing.T) {
+	var (
+		l   *Logger
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	entries, errs := Stream(ctx, filename)
+	<-entries
+	cancel()
+
+	// No further entries are received, so the stream observes the cancellation
+	if err = <-errs; err != context.Canceled {
+		t.Fatalf("invalid error, expected %v and received %v", context.Canceled, err)
+	}
+
+	if _, ok := <-entries; ok {
+		t.Fatal("invalid entries channel state, expected closed and received open")
+	}
+}
+
+func testStream(filename string, expected int) (err error) {
+	entries, errs := Stream(context.Background(), filename)
+
+	var count int
+	for e := range entries {
+		if count++; e.Message != fmt.Sprintf("#%d", count) {
+			return fmt.Errorf("invalid message, expected #%d and received %s", count, e.Message)
+		}
+	}
+
+	if err = <-errs; err != nil {
+		return
+	}
+
+	if count != expected {
+		return fmt.Errorf("invalid number of entries, expected %d and received %d", expected, count)
+	}
+
+	return
+}
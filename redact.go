@@ -0,0 +1,31 @@
+package logger
+
+import "regexp"
+
+var (
+	// EmailPattern matches email addresses (E.g. jane@example.com)
+	EmailPattern = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	// IPv4Pattern matches IPv4 addresses (E.g. 192.168.0.1)
+	IPv4Pattern = regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`)
+	// CreditCardPattern matches 13 to 16 digit card numbers, optionally separated by spaces or dashes
+	CreditCardPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,15}\b`)
+	// SSNPattern matches US social security numbers (E.g. 123-45-6789)
+	SSNPattern = regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)
+)
+
+// NewRedactHook will return a hook which replaces all matches of the provided patterns with replacement
+// Note: Patterns are applied in order. Messages which do not match any pattern are returned without allocating
+func NewRedactHook(patterns []*regexp.Regexp, replacement []byte) Hook {
+	return func(msg []byte) ([]byte, error) {
+		for _, pattern := range patterns {
+			if !pattern.Match(msg) {
+				// Pattern does not match, avoid allocating a replacement
+				continue
+			}
+
+			msg = pattern.ReplaceAllLiteral(msg, replacement)
+		}
+
+		return msg, nil
+	}
+}
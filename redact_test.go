@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewRedactHook(t *testing.T) {
+	var (
+		out []byte
+		err error
+	)
+
+	type testcase struct {
+		msg      string
+		expected string
+	}
+
+	tcs := []testcase{
+		{msg: "user jane.doe+test@example.com signed in", expected: "user [REDACTED] signed in"},
+		{msg: "request from 192.168.0.1 and 10.0.0.255", expected: "request from [REDACTED] and [REDACTED]"},
+		{msg: "charged card 4111 1111 1111 1111", expected: "charged card [REDACTED]"},
+		{msg: "ssn 123-45-6789 verified", expected: "ssn [REDACTED] verified"},
+		{msg: "nothing to redact", expected: "nothing to redact"},
+	}
+
+	patterns := []*regexp.Regexp{EmailPattern, IPv4Pattern, CreditCardPattern, SSNPattern}
+	redact := NewRedactHook(patterns, []byte("[REDACTED]"))
+
+	for _, tc := range tcs {
+		if out, err = redact([]byte(tc.msg)); err != nil {
+			t.Fatal(err)
+		}
+
+		if string(out) != tc.expected {
+			t.Fatalf("invalid message, expected \"%s\" and received \"%s\"", tc.expected, out)
+		}
+
+		for _, pattern := range patterns {
+			if pattern.Match(out) {
+				t.Fatalf("expected no match of %v to remain within \"%s\"", pattern, out)
+			}
+		}
+	}
+}
+
+func TestNewRedactHook_noMatch(t *testing.T) {
+	msg := []byte("nothing to redact")
+	redact := NewRedactHook([]*regexp.Regexp{EmailPattern}, []byte("***"))
+
+	out, err := redact(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if &out[0] != &msg[0] || !bytes.Equal(out, msg) {
+		t.Fatal("expected unmatched message to be returned as is")
+	}
+}
+
+func TestNewRedactHook_logger(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.AddHook(NewRedactHook([]*regexp.Regexp{EmailPattern}, []byte("***"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("password reset sent to jane@example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "password reset sent to ***"
+	if len(logs) != 1 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 1, len(logs))
+	}
+
+	if logs[0] != expected {
+		t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected, logs[0])
+	}
+
+	if EmailPattern.MatchString(logs[0]) {
+		t.Fatalf("expected no email address to remain within \"%s\"", logs[0])
+	}
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hatchify/errors"
+)
+
+// registry is the package-level registry of named loggers
+var registry = struct {
+	mu      sync.RWMutex
+	loggers map[string]*Logger
+}{
+	loggers: make(map[string]*Logger),
+}
+
+// Register will register a logger with the provided name, allowing it to be retrieved with Get
+func Register(name string, l *Logger) (err error) {
+	if l == nil {
+		return ErrInvalidLogger
+	}
+
+	// Acquire lock
+	registry.mu.Lock()
+	// Defer the release of our lock
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.loggers[name]; ok {
+		return ErrAlreadyRegistered
+	}
+
+	registry.loggers[name] = l
+	return
+}
+
+// Unregister will remove the logger registered with the provided name (if it exists)
+// Note: The logger is not closed
+func Unregister(name string) {
+	// Acquire lock
+	registry.mu.Lock()
+	// Defer the release of our lock
+	defer registry.mu.Unlock()
+	delete(registry.loggers, name)
+}
+
+// Get will return the logger registered with the provided name
+func Get(name string) (l *Logger, ok bool) {
+	// Acquire read lock
+	registry.mu.RLock()
+	// Defer the release of our read lock
+	defer registry.mu.RUnlock()
+	l, ok = registry.loggers[name]
+	return
+}
+
+// MustGet will return the logger registered with the provided name, panicking if it does not exist
+func MustGet(name string) *Logger {
+	l, ok := Get(name)
+	if !ok {
+		panic(fmt.Sprintf("logger \"%s\" is not registered", name))
+	}
+
+	return l
+}
+
+// CloseAll will close and unregister every registered logger
+// Note: All loggers are closed even if some return an error, the errors are returned as an *errors.ErrorList
+func CloseAll() (err error) {
+	// Acquire lock
+	registry.mu.Lock()
+	loggers := registry.loggers
+	registry.loggers = make(map[string]*Logger)
+	// Release lock, loggers are closed without holding the lock
+	registry.mu.Unlock()
+
+	names := make([]string, 0, len(loggers))
+	for name := range loggers {
+		names = append(names, name)
+	}
+
+	// Close loggers in name order so errors are returned in a consistent order
+	sort.Strings(names)
+
+	var errs errors.ErrorList
+	for _, name := range names {
+		if err = loggers[name].Close(); err != nil {
+			errs.Push(fmt.Errorf("error closing logger \"%s\": %v", name, err))
+		}
+	}
+
+	return errs.Err()
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/hatchify/errors"
+)
+
+func TestRegistry(t *testing.T) {
+	var (
+		wg sync.WaitGroup
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	errC := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("%s-%d", testName, i)
+			l, err := New(testDir, name)
+			if err != nil {
+				errC <- err
+				return
+			}
+
+			if err = Register(name, l); err != nil {
+				errC <- err
+				return
+			}
+
+			if got, ok := Get(name); !ok || got != l {
+				errC <- fmt.Errorf("invalid logger for \"%s\"", name)
+				return
+			}
+
+			MustGet(name)
+		}(i)
+	}
+
+	wg.Wait()
+	close(errC)
+	for err = range errC {
+		t.Fatal(err)
+	}
+
+	if err = Register(testName+"-0", Discard()); err != ErrAlreadyRegistered {
+		t.Fatalf("invalid error, expected %v and received %v", ErrAlreadyRegistered, err)
+	}
+
+	unregistered := MustGet(testName + "-9")
+	defer unregistered.Close()
+
+	Unregister(testName + "-9")
+	if _, ok := Get(testName + "-9"); ok {
+		t.Fatal("expected logger to be unregistered")
+	}
+
+	// Close two loggers early so CloseAll receives errors from both
+	MustGet(testName + "-0").Close()
+	MustGet(testName + "-1").Close()
+
+	err = CloseAll()
+	errs, ok := err.(*errors.ErrorList)
+	if !ok {
+		t.Fatalf("invalid error, expected an error list and received %v", err)
+	}
+
+	if errs.Len() != 2 {
+		t.Fatalf("invalid number of errors, expected %d and received %d", 2, errs.Len())
+	}
+
+	if _, ok = Get(testName + "-2"); ok {
+		t.Fatal("expected loggers to be unregistered after CloseAll")
+	}
+}
+
+func TestMustGet(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected MustGet to panic for an unregistered logger")
+		}
+	}()
+
+	MustGet("missing")
+}
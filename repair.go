@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+// RepairFile will truncate the provided log file at its last complete line, removing an incomplete line left by a
+// process which crashed mid-write
+// Note: Complete lines which do not contain a separator are counted and reported to stderr, but are not removed as
+// they may have been written in a format without a separator (E.g. JSON). Only the text formats are supported
+func RepairFile(path string) (linesRemoved int, err error) {
+	var f *os.File
+	if f, err = os.Open(path); err != nil {
+		return
+	}
+	defer f.Close()
+
+	var (
+		// Offset of the end of the last complete line
+		end int64
+		// Number of complete lines which do not contain a separator
+		missingSeparator int
+	)
+
+	r := bufio.NewReader(f)
+	for {
+		var line []byte
+		if line, err = r.ReadBytes('\n'); err == io.EOF {
+			break
+		} else if err != nil {
+			return
+		}
+
+		end += int64(len(line))
+		if bytes.IndexByte(line, defaultSeparator) == -1 {
+			missingSeparator++
+		}
+	}
+
+	if missingSeparator > 0 {
+		fmt.Fprintf(os.Stderr, "logger: %s contains %d line(s) without a separator\n", path, missingSeparator)
+	}
+
+	var info os.FileInfo
+	if info, err = f.Stat(); err != nil {
+		return
+	}
+
+	if info.Size() == end {
+		// File ends with a complete line (or is empty), return
+		return 0, nil
+	}
+
+	// File ends with an incomplete line, truncate the file at the end of the last complete line
+	if err = os.Truncate(path, end); err != nil {
+		return
+	}
+
+	return 1, nil
+}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestRepairFile(t *testing.T) {
+	var (
+		l   *Logger
+		bs  []byte
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = testLogs(l, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	var expected []byte
+	if expected, err = ioutil.ReadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a crash while writing an entry
+	var f *os.File
+	if f, err = os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = f.WriteString("1600000000000000000@INFO@partial ent"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var removed int
+	if removed, err = RepairFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if removed != 1 {
+		t.Fatalf("invalid number of lines removed, expected %d and received %d", 1, removed)
+	}
+
+	if bs, err = ioutil.ReadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(bs) != string(expected) {
+		t.Fatalf("invalid contents, expected %q and received %q", expected, bs)
+	}
+
+	// File is now complete, repairing again is a no-op
+	if removed, err = RepairFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if removed != 0 {
+		t.Fatalf("invalid number of lines removed, expected %d and received %d", 0, removed)
+	}
+}
+
+func TestRepairFile_missing_separator(t *testing.T) {
+	var (
+		bs  []byte
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	filename := path.Join(testDir, testName+".log")
+	contents := "1600000000000000000@#1\n{\"msg\":\"#2\"}\n{\"msg\":"
+	if err = ioutil.WriteFile(filename, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var removed int
+	if removed, err = RepairFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if removed != 1 {
+		t.Fatalf("invalid number of lines removed, expected %d and received %d", 1, removed)
+	}
+
+	if bs, err = ioutil.ReadFile(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	// Lines without a separator are retained
+	if expected := "1600000000000000000@#1\n{\"msg\":\"#2\"}\n"; string(bs) != expected {
+		t.Fatalf("invalid contents, expected %q and received %q", expected, bs)
+	}
+}
@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"time"
+)
+
+// newRetention will return a new retention policy for the logger's current settings
+func newRetention(l *Logger) (r retention) {
+	r.dir = l.dir
+	r.name = l.name
+	r.current = l.filename
+	r.currentTS, _ = parseFilename(path.Base(l.filename), l.name)
+	r.count = l.retainCount
+	r.duration = l.retainDuration
+	r.maxDiskUsage = l.maxDiskUsage
+	r.onError = l.errorHandler()
+	return
+}
+
+// retention represents a snapshot of the retention policy at the time of a rotation
+type retention struct {
+	dir     string
+	name    string
+	current string
+	// Timestamp of the current file, files created after the current file are not considered
+	currentTS time.Time
+
+	// Maximum number of files to retain (excluding the current file)
+	count int
+	// Maximum age of files to retain
+	duration time.Duration
+	// Maximum number of bytes used by all files (including the current file)
+	maxDiskUsage int64
+
+	onError ErrorFn
+}
+
+// apply will delete the files which fall outside of the retention policy
+// Note: This is intended to be called within a goroutine and must not hold the Logger mutex
+func (r *retention) apply() {
+	files, err := listFiles(r.dir, r.name)
+	if err != nil {
+		r.onError(fmt.Errorf("error listing files for retention: %v", err))
+		return
+	}
+
+	// Filter out the currently opened file and any files created by later rotations
+	// Note: Later rotations apply their own retention, including them here would remove files the later policy retains
+	retained := files[:0]
+	for _, file := range files {
+		if file.filename == r.current || (!r.currentTS.IsZero() && file.ts.After(r.currentTS)) {
+			continue
+		}
+
+		retained = append(retained, file)
+	}
+
+	if r.duration > 0 {
+		// Remove files which are older than our retention duration
+		cutoff := time.Now().Add(-r.duration)
+		for len(retained) > 0 && retained[0].ts.Before(cutoff) {
+			r.remove(retained[0].filename)
+			retained = retained[1:]
+		}
+	}
+
+	if r.count > 0 {
+		// Remove the oldest files which exceed our retention count
+		for len(retained) > r.count {
+			r.remove(retained[0].filename)
+			retained = retained[1:]
+		}
+	}
+
+	if r.maxDiskUsage > 0 {
+		// Remove the oldest files until disk usage is within our limit
+		r.applyDiskUsage(retained)
+	}
+}
+
+// applyDiskUsage will remove the oldest of the provided files until the total size of the files and the current file
+// is within the maximum disk usage
+// Note: The current file is never removed, so usage may still exceed the limit if the current file alone exceeds it
+func (r *retention) applyDiskUsage(files []logFile) {
+	total := getFileSize(r.current)
+	sizes := make([]int64, len(files))
+	for i, file := range files {
+		sizes[i] = getFileSize(file.filename)
+		total += sizes[i]
+	}
+
+	for i := 0; i < len(files) && total > r.maxDiskUsage; i++ {
+		r.remove(files[i].filename)
+		total -= sizes[i]
+	}
+}
+
+// remove will remove a file, passing any unexpected errors to the error handler
+func (r *retention) remove(filename string) {
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		r.onError(fmt.Errorf("error removing file for retention: %v", err))
+	}
+
+	// Remove checksum sidecar (if it exists)
+	os.Remove(filename + checksumExt)
+}
+
+// getFileSize will return the size of a file, zero is returned if the file cannot be stat'd (E.g. removed by a
+// concurrent compression)
+func getFileSize(filename string) int64 {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return 0
+	}
+
+	return info.Size()
+}
@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetainCount(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetNumLines(1)
+	l.SetRetainCount(2)
+
+	if err = testLogs(l, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = waitForFileCount(2); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetainDuration(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	old := time.Now().Add(-time.Hour * 2).UnixNano()
+	oldFilename := path.Join(testDir, fmt.Sprintf("%s.%d.log", testName, old))
+	if err = ioutil.WriteFile(oldFilename, []byte("1@old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetNumLines(1)
+	l.SetRetainDuration(time.Hour)
+
+	if err = testLogs(l, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = waitForRemoval(oldFilename); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = waitForFileCount(3); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitForFileCount(n int) (err error) {
+	var files []logFile
+	for i := 0; i < 100; i++ {
+		if files, err = listFiles(testDir, testName); err != nil {
+			return
+		}
+
+		if len(files) == n {
+			return
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	return fmt.Errorf("invalid number of files, expected %d and received %d", n, len(files))
+}
+
+func TestSetMaxDiskUsage(t *testing.T) {
+	var (
+		l *Logger
+
+		infos []os.FileInfo
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each file contains 5 entries of just over 1KB
+	limit := int64(12000)
+	l.SetNumLines(5)
+	l.SetMaxDiskUsage(limit)
+
+	msg := strings.Repeat("a", 1000)
+	for i := 0; i < 50; i++ {
+		if err = l.LogString(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Close waits for retention to complete
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if infos, err = ioutil.ReadDir(testDir); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int64
+	for _, info := range infos {
+		total += info.Size()
+	}
+
+	if total == 0 || total > limit {
+		t.Fatalf("invalid disk usage, expected at most %d and received %d", limit, total)
+	}
+
+	if len(infos) != 2 {
+		t.Fatalf("invalid number of files, expected %d and received %d", 2, len(infos))
+	}
+}
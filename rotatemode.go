@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"io"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+const (
+	// RotateModeNewFile will rotate by closing the current file and opening a new file (default)
+	RotateModeNewFile RotateMode = iota
+	// RotateModeTruncate will rotate by truncating the current file in place, for use with logrotate's copytruncate
+	RotateModeTruncate
+)
+
+// RotateMode represents how a file is rotated
+type RotateMode uint8
+
+// isValid will return whether or not the rotate mode is a known mode
+func (r RotateMode) isValid() bool {
+	return r <= RotateModeTruncate
+}
+
+// truncateFile will flush and truncate the current file, resetting the line count and size
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) truncateFile() (err error) {
+	// Flush contents, so buffered entries are not written after the truncation
+	if err = l.flush(); err != nil {
+		return
+	}
+
+	// Truncate file to zero length
+	if err = l.f.Truncate(0); err != nil {
+		return
+	}
+
+	// Seek to the beginning of the file
+	if _, err = l.f.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+
+	// Reset count and size to zero
+	l.count = 0
+	l.size = 0
+	// Reset the time the file was opened, used by the manifest
+	l.openedAt = time.Now()
+
+	// Update rotation stats
+	l.stats.TotalRotations++
+	l.stats.LastRotationTime = time.Now()
+	return
+}
+
+// SetRotateMode will set how files are rotated (defaults to RotateModeNewFile)
+// Note: When set to RotateModeTruncate, the current file is truncated in place rather than a new file being opened.
+// Entries are discarded on rotation, so this is intended for use with an external copy (E.g. logrotate's
+// copytruncate). Rotate functions, rotation hooks, compression and retention do not apply to truncated files
+func (l *Logger) SetRotateMode(mode RotateMode) (err error) {
+	// Ensure rotate mode is valid
+	if !mode.isValid() {
+		return ErrInvalidRotateMode
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.rotateMode = mode
+	return
+}
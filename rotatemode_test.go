@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSetRotateMode(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		before os.FileInfo
+		after  os.FileInfo
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetRotateMode(RotateMode(10)); err != ErrInvalidRotateMode {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidRotateMode, err)
+	}
+
+	if err = l.SetRotateMode(RotateModeTruncate); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetNumLines(2)
+
+	filename := l.f.Name()
+	if before, err = os.Stat(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testLogs(l, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	if after, err = os.Stat(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if !os.SameFile(before, after) {
+		t.Fatal("expected file to remain the same after rotation")
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logs) != 1 || logs[0] != "#3" {
+		t.Fatalf("invalid logs, expected %v and received %v", []string{"#3"}, logs)
+	}
+}
@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"sync"
+
+	"github.com/hatchify/errors"
+)
+
+// NewRouter will return a new instance of Router
+func NewRouter() *Router {
+	var r Router
+	return &r
+}
+
+// Router will route log messages to loggers by level (E.g. debug messages to one file, errors to another)
+type Router struct {
+	mu sync.RWMutex
+
+	routes []route
+	// Closed state
+	closed bool
+}
+
+// route represents a logger which receives messages within a level range (inclusive)
+type route struct {
+	min Level
+	max Level
+	l   *Logger
+}
+
+// includes will return whether or not the route's level range includes the provided level
+func (r *route) includes(level Level) bool {
+	return level >= r.min && level <= r.max
+}
+
+// AddRoute will route messages with a level between minLevel and maxLevel (inclusive) to the provided logger
+// Note: A logger may be added to multiple routes, messages within overlapping ranges are written once per route
+func (r *Router) AddRoute(minLevel, maxLevel Level, l *Logger) (err error) {
+	if !minLevel.isValid() || !maxLevel.isValid() {
+		return ErrInvalidLevel
+	}
+
+	if minLevel > maxLevel {
+		return ErrInvalidLevelRange
+	}
+
+	// Acquire lock
+	r.mu.Lock()
+	// Defer the release of our lock
+	defer r.mu.Unlock()
+
+	if r.closed {
+		// Router has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	r.routes = append(r.routes, route{min: minLevel, max: maxLevel, l: l})
+	return
+}
+
+// Log will log a message to all loggers whose level range includes the provided level
+// Note: A failing logger will not prevent the message from being written to the others
+func (r *Router) Log(level Level, msg []byte) (err error) {
+	if !level.isValid() {
+		return ErrInvalidLevel
+	}
+
+	// Acquire read lock
+	r.mu.RLock()
+	// Defer the release of our read lock
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		// Router has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	var errs errors.ErrorList
+	for i := range r.routes {
+		if rt := &r.routes[i]; rt.includes(level) {
+			errs.Push(rt.l.log(level, msg))
+		}
+	}
+
+	return errs.Err()
+}
+
+// LogString will log a string message to all loggers whose level range includes the provided level
+func (r *Router) LogString(level Level, msg string) (err error) {
+	// Convert message to bytes and pass to r.Log
+	return r.Log(level, []byte(msg))
+}
+
+// Close will close all registered loggers
+func (r *Router) Close() (err error) {
+	// Acquire lock
+	r.mu.Lock()
+	// Defer the release of our lock
+	defer r.mu.Unlock()
+
+	if r.closed {
+		// Router has already been closed, return
+		return errors.ErrIsClosed
+	}
+
+	r.closed = true
+
+	var errs errors.ErrorList
+	closed := make(map[*Logger]bool, len(r.routes))
+	for _, rt := range r.routes {
+		if closed[rt.l] {
+			// Logger was added to multiple routes and has already been closed, continue
+			continue
+		}
+
+		closed[rt.l] = true
+		errs.Push(rt.l.Close())
+	}
+
+	return errs.Err()
+}
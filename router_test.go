@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/hatchify/errors"
+)
+
+func TestRouter(t *testing.T) {
+	var (
+		debug *Logger
+		errL  *Logger
+		err   error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if debug, err = New(testDir, testName+"_debug"); err != nil {
+		t.Fatal(err)
+	}
+
+	if errL, err = New(testDir, testName+"_error"); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRouter()
+	if err = r.AddRoute(LevelError, LevelDebug, debug); err != ErrInvalidLevelRange {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidLevelRange, err)
+	}
+
+	if err = r.AddRoute(LevelDebug, LevelWarn, debug); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = r.AddRoute(LevelError, LevelFatal, errL); err != nil {
+		t.Fatal(err)
+	}
+
+	debugFilename, errorFilename := debug.f.Name(), errL.f.Name()
+	for i := 0; i < 5; i++ {
+		if err = r.LogString(LevelDebug, fmt.Sprintf("debug #%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = r.LogString(LevelError, fmt.Sprintf("error #%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = r.LogString(LevelDebug, "closed"); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+
+	if err = testRoutedEntries(debugFilename, LevelDebug, "debug"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = testRoutedEntries(errorFilename, LevelError, "error"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testRoutedEntries(filename string, level Level, prefix string) (err error) {
+	var r *Reader
+	if r, err = NewReader(filename); err != nil {
+		return
+	}
+	defer r.Close()
+
+	var count int
+	for {
+		var e Entry
+		if e, err = r.Next(); err == io.EOF {
+			break
+		} else if err != nil {
+			return
+		}
+
+		count++
+		if e.Level != level.name() {
+			return fmt.Errorf("invalid level, expected %s and received %s", level.name(), e.Level)
+		}
+
+		if expected := fmt.Sprintf("%s #%d", prefix, count); e.Message != expected {
+			return fmt.Errorf("invalid message, expected %s and received %s", expected, e.Message)
+		}
+	}
+
+	if count != 5 {
+		return fmt.Errorf("invalid number of entries, expected %d and received %d", 5, count)
+	}
+
+	return nil
+}
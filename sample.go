@@ -0,0 +1,41 @@
+package logger
+
+import "github.com/hatchify/errors"
+
+// sample will return whether or not a message should be written under the current sample rate
+// Note: The sample count is incremented for every call (written or not), so sampling is evenly distributed
+func (l *Logger) sample(level Level) bool {
+	if level == LevelFatal {
+		// Fatal messages always bypass sampling
+		return true
+	}
+
+	rate := l.sampleRate.Load()
+	if rate <= 1 {
+		// Sampling is not enabled, write every message
+		return true
+	}
+
+	// Write the first message of every rate messages
+	return (l.sampleCount.Add(1)-1)%rate == 0
+}
+
+// SetSampleRate will set the logger to write 1 in every n messages, messages which are sampled out are dropped
+// without returning an error
+// Note: A sample rate of 1 writes every message (default). Fatal messages are always written
+func (l *Logger) SetSampleRate(n int) (err error) {
+	if n < 1 {
+		return ErrInvalidSampleRate
+	}
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.sampleRate.Store(uint64(n))
+	// Reset the sample count so the next message is written
+	l.sampleCount.Store(0)
+	return
+}
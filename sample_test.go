@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSetSampleRate(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetSampleRate(0); err != ErrInvalidSampleRate {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidSampleRate, err)
+	}
+
+	if err = l.SetSampleRate(10); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 100; i++ {
+		if err = l.Info([]byte(fmt.Sprintf("#%d", i+1))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Fatal messages bypass sampling, log is called directly as Fatal exits the process
+	for i := 0; i < 3; i++ {
+		if err = l.log(LevelFatal, []byte("fatal")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var expected []string
+	for i := 0; i < 100; i += 10 {
+		expected = append(expected, fmt.Sprintf("INFO @#%d", i+1))
+	}
+
+	expected = append(expected, "FATAL@fatal", "FATAL@fatal", "FATAL@fatal")
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package logger
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+const (
+	// dateLayout is the time layout used for filenames when midnight rotation is enabled
+	dateLayout = "2006-01-02"
+	// hourLayout is the time layout used for filenames when hourly rotation is enabled
+	hourLayout = "2006-01-02-15"
+)
+
+// timeNow returns the current time for scheduled rotations and filenames, replaceable for testing
+var timeNow = time.Now
+
+// untilMidnight will return the duration until the coming local midnight
+func untilMidnight(now time.Time) time.Duration {
+	year, month, day := now.Date()
+	midnight := time.Date(year, month, day+1, 0, 0, 0, 0, now.Location())
+	return midnight.Sub(now)
+}
+
+// untilNextHour will return the duration until the coming full hour
+func untilNextHour(now time.Time) time.Duration {
+	year, month, day := now.Date()
+	hour := time.Date(year, month, day, now.Hour()+1, 0, 0, 0, now.Location())
+	return hour.Sub(now)
+}
+
+// getDatedFilename will return the filename for the provided date based stamp (E.g. name.2006-01-02.log), further files
+// opened by this logger with the same stamp include a sequence number (E.g. name.2006-01-02.1.log)
+// Note: Files closed by this logger are never re-opened, as they may still be processed (E.g. compressed) after close.
+// The first file for a stamp may be shared with other processes (see SetFileLock)
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) getDatedFilename(stamp string) (filename string) {
+	base := path.Join(l.dir, l.name) + "." + stamp
+	if base != l.datedBase {
+		// Stamp (or name) has changed, reset sequence
+		l.datedBase = base
+		l.datedSeq = 0
+	} else {
+		l.datedSeq++
+	}
+
+	for {
+		if filename = base + ".log"; l.datedSeq > 0 {
+			filename = base + "." + strconv.Itoa(l.datedSeq) + ".log"
+		}
+
+		if filename != l.filename && !fileExists(filename+compressedExt) {
+			// Filename is not the current file and has not been compressed, return
+			return
+		}
+
+		l.datedSeq++
+	}
+}
+
+// parseDatedStamp will parse a date based stamp with an optional sequence number (E.g. 2006-01-02 or 2006-01-02.1)
+// Note: The sequence number is added as nanoseconds, so files within the same day (or hour) sort in the order they were
+// created
+func parseDatedStamp(stamp, layout string) (ts time.Time, ok bool) {
+	var seq int
+	if i := strings.LastIndexByte(stamp, '.'); i != -1 {
+		var err error
+		if seq, err = strconv.Atoi(stamp[i+1:]); err != nil || seq < 1 {
+			return
+		}
+
+		stamp = stamp[:i]
+	}
+
+	date, err := time.ParseInLocation(layout, stamp, time.Local)
+	if err != nil {
+		return
+	}
+
+	return date.Add(time.Duration(seq)), true
+}
+
+// midnightRotate will rotate the log file and schedule the next midnight rotation
+func (l *Logger) midnightRotate() {
+	// Acquire lock
+	l.mu.Lock()
+	err := l.rotateAtMidnight()
+	// Release lock
+	l.unlock()
+
+	if err != nil {
+		// We encountered an unexpected error, pass to the error handler
+		l.handleError(fmt.Errorf("error rotating file at midnight: %v", err))
+	}
+}
+
+// rotateAtMidnight will rotate the log file and schedule the next midnight rotation
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) rotateAtMidnight() (err error) {
+	// Ensure the logger has not been closed and midnight rotation is still enabled
+	if l.isClosed() || l.midnightTimer == nil {
+		return
+	}
+
+	// Schedule the next midnight rotation
+	l.midnightTimer = time.AfterFunc(untilMidnight(timeNow()), l.midnightRotate)
+	// Set a new underlying log file, named with the new date
+	return l.setFile(RotationReasonTime)
+}
+
+// stopMidnightTimer will stop the midnight rotation timer (if set)
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) stopMidnightTimer() {
+	if l.midnightTimer == nil {
+		return
+	}
+
+	l.midnightTimer.Stop()
+	l.midnightTimer = nil
+}
+
+// SetRotateAtMidnight will set whether or not the log file is rotated at each local midnight
+// Note: While enabled, new files are named using the current date (E.g. name.YYYY-MM-DD.log) rather than a unix
+// timestamp. Further files within the same day (E.g. from line limits or Rotate) are named with a sequence number (E.g.
+// name.YYYY-MM-DD.1.log). This can be used alongside SetRotateInterval, whichever triggers first will rotate the file
+func (l *Logger) SetRotateAtMidnight(rotateAtMidnight bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	switch {
+	case !rotateAtMidnight:
+		// Midnight rotation is being disabled, stop timer
+		l.stopMidnightTimer()
+	case l.midnightTimer == nil:
+		// Midnight rotation is being enabled, schedule the next midnight rotation
+		l.midnightTimer = time.AfterFunc(untilMidnight(timeNow()), l.midnightRotate)
+	}
+
+	return
+}
+
+// hourlyRotate will rotate the log file and schedule the next hourly rotation
+func (l *Logger) hourlyRotate() {
+	// Acquire lock
+	l.mu.Lock()
+	err := l.rotateHourly()
+	// Release lock
+	l.unlock()
+
+	if err != nil {
+		// We encountered an unexpected error, pass to the error handler
+		l.handleError(fmt.Errorf("error rotating file on the hour: %v", err))
+	}
+}
+
+// rotateHourly will rotate the log file and schedule the next hourly rotation
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) rotateHourly() (err error) {
+	// Ensure the logger has not been closed and hourly rotation is still enabled
+	if l.isClosed() || l.hourlyTimer == nil {
+		return
+	}
+
+	// Schedule the next hourly rotation, the duration is computed from the current time so timer delays do not drift
+	l.hourlyTimer = time.AfterFunc(untilNextHour(timeNow()), l.hourlyRotate)
+	// Set a new underlying log file, named with the new hour
+	return l.setFile(RotationReasonTime)
+}
+
+// stopHourlyTimer will stop the hourly rotation timer (if set)
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) stopHourlyTimer() {
+	if l.hourlyTimer == nil {
+		return
+	}
+
+	l.hourlyTimer.Stop()
+	l.hourlyTimer = nil
+}
+
+// SetRotateHourly will set whether or not the log file is rotated at the start of each local hour
+// Note: While enabled, new files are named using the current date and hour (E.g. name.YYYY-MM-DD-HH.log) rather than
+// a unix timestamp, this takes precedence over the date based names of SetRotateAtMidnight. Further files within the
+// same hour are named with a sequence number (E.g. name.YYYY-MM-DD-HH.1.log). This can be used alongside
+// SetRotateInterval, whichever triggers first will rotate the file
+func (l *Logger) SetRotateHourly(rotateHourly bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	switch {
+	case !rotateHourly:
+		// Hourly rotation is being disabled, stop timer
+		l.stopHourlyTimer()
+	case l.hourlyTimer == nil:
+		// Hourly rotation is being enabled, schedule the next hourly rotation
+		l.hourlyTimer = time.AfterFunc(untilNextHour(timeNow()), l.hourlyRotate)
+	}
+
+	return
+}
@@ -0,0 +1,307 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestUntilMidnight(t *testing.T) {
+	now := time.Date(2020, 1, 31, 22, 30, 0, 0, time.Local)
+	if d := untilMidnight(now); d != time.Minute*90 {
+		t.Fatalf("invalid duration, expected %v and received %v", time.Minute*90, d)
+	}
+}
+
+func TestRotateAtMidnight(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		lineCount int
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetRotateAtMidnight(true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate the midnight timer firing
+	l.midnightRotate()
+
+	expected := path.Join(testDir, testName+"."+time.Now().Format(dateLayout)+".log")
+	if filename := l.f.Name(); filename != expected {
+		t.Fatalf("invalid filename, expected \"%s\" and received \"%s\"", expected, filename)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rotate twice within the same day, the closed file is not re-opened and the empty sequenced file is replaced
+	for i := 0; i < 2; i++ {
+		if err = l.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(expected); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		lineCount++
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if lineCount != 1 {
+		t.Fatalf("invalid line count, expected %d and received %d", 1, lineCount)
+	}
+
+	if l.midnightTimer != nil {
+		t.Fatal("expected midnight timer to be stopped on close")
+	}
+}
+
+func TestRotateAtMidnight_Compress(t *testing.T) {
+	var (
+		l *Logger
+
+		filenames []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 8)
+	l.SetErrorHandler(func(err error) { errs <- err })
+
+	if err = l.SetRotateAtMidnight(true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate the midnight timer firing
+	l.midnightRotate()
+	l.SetCompressOnRotate(true)
+
+	for i, log := range []string{"a", "b", "c"} {
+		if i > 0 {
+			// Rotate within the same day, the closed file is compressed while the next file is written
+			if err = l.Rotate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		filenames = append(filenames, l.f.Name())
+		if err = l.LogString(log); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	date := time.Now().Format(dateLayout)
+	for i, suffix := range []string{"", ".1", ".2"} {
+		if expected := path.Join(testDir, testName+"."+date+suffix+".log"); filenames[i] != expected {
+			t.Fatalf("invalid filename, expected \"%s\" and received \"%s\"", expected, filenames[i])
+		}
+	}
+
+	for i, filename := range filenames {
+		if err = waitForRemoval(filename); err != nil {
+			t.Fatal(err)
+		}
+
+		var bs []byte
+		if bs, err = readCompressed(filename + compressedExt); err != nil {
+			t.Fatal(err)
+		}
+
+		_, log, err := parseLine(bytes.TrimSuffix(bs, newline))
+		if expected := []string{"a", "b", "c"}[i]; err != nil || string(log) != expected {
+			t.Fatalf("invalid compressed contents, expected \"%s\" and received \"%s\"", expected, bs)
+		}
+	}
+
+	select {
+	case err = <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	default:
+	}
+}
+
+func TestUntilNextHour(t *testing.T) {
+	now := time.Date(2020, 1, 31, 23, 47, 30, 0, time.Local)
+	if d := untilNextHour(now); d != time.Second*750 {
+		t.Fatalf("invalid duration, expected %v and received %v", time.Second*750, d)
+	}
+}
+
+func TestRotateHourly(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	// Mock the current time to be shortly before the hour
+	start := time.Now()
+	base := time.Date(2020, 1, 31, 13, 59, 59, int(time.Millisecond*900), time.Local)
+	timeNow = func() time.Time { return base.Add(time.Since(start)) }
+	defer func() { timeNow = time.Now }()
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetRotateHourly(true); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := path.Join(testDir, testName+".2020-01-31-14.log")
+	deadline := time.Now().Add(time.Second * 5)
+	for l.Stats().TotalRotations == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	if n := l.Stats().TotalRotations; n != 1 {
+		t.Fatalf("invalid number of rotations, expected %d and received %d", 1, n)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Millisecond*100 {
+		t.Fatalf("invalid rotation time, expected rotation after %v and received %v", time.Millisecond*100, elapsed)
+	}
+
+	var filename string
+	if filename, err = l.currentFilename(); err != nil {
+		t.Fatal(err)
+	}
+
+	if filename != expected {
+		t.Fatalf("invalid filename, expected \"%s\" and received \"%s\"", expected, filename)
+	}
+
+	if ts, ok := parseFilename(path.Base(expected), testName); !ok || !ts.Equal(base.Add(time.Millisecond*100)) {
+		t.Fatalf("invalid parsed timestamp, received %v", ts)
+	}
+
+	if err = l.SetRotateHourly(false); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.hourlyTimer != nil {
+		t.Fatal("expected hourly timer to be stopped")
+	}
+}
+
+func TestRotateHourly_Compress(t *testing.T) {
+	var (
+		l *Logger
+
+		filenames []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	// Mock the current time to be within the hour, so the hourly timer does not fire during the test
+	now := time.Date(2020, 1, 31, 13, 30, 0, 0, time.Local)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(chan error, 8)
+	l.SetErrorHandler(func(err error) { errs <- err })
+
+	if err = l.SetRotateHourly(true); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetCompressOnRotate(true)
+
+	for _, log := range []string{"a", "b", "c"} {
+		// Rotate within the same hour, the closed file is compressed while the next file is written
+		if err = l.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+
+		filenames = append(filenames, l.f.Name())
+		if err = l.LogString(log); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, suffix := range []string{"", ".1", ".2"} {
+		if expected := path.Join(testDir, testName+".2020-01-31-13"+suffix+".log"); filenames[i] != expected {
+			t.Fatalf("invalid filename, expected \"%s\" and received \"%s\"", expected, filenames[i])
+		}
+	}
+
+	for i, filename := range filenames {
+		if err = waitForRemoval(filename); err != nil {
+			t.Fatal(err)
+		}
+
+		var bs []byte
+		if bs, err = readCompressed(filename + compressedExt); err != nil {
+			t.Fatal(err)
+		}
+
+		_, log, err := parseLine(bytes.TrimSuffix(bs, newline))
+		if expected := []string{"a", "b", "c"}[i]; err != nil || string(log) != expected {
+			t.Fatalf("invalid compressed contents, expected \"%s\" and received \"%s\"", expected, bs)
+		}
+	}
+
+	select {
+	case err = <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	default:
+	}
+}
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/hatchify/errors"
+)
+
+// signalListener will rotate a logger whenever one of it's signals is received
+type signalListener struct {
+	signals chan os.Signal
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// listen will rotate the logger on each received signal until the listener is stopped
+func (s *signalListener) listen(l *Logger) {
+	// Signal that the listener has exited once the loop ends
+	defer close(s.done)
+
+	for {
+		select {
+		case <-s.signals:
+			switch err := l.Rotate(); err {
+			case nil:
+			case errors.ErrIsClosed:
+				// Instance of logger is closed, we can bail out completely
+				return
+
+			default:
+				// We encountered an unexpected error, pass to the error handler
+				l.handleError(fmt.Errorf("error rotating file on signal: %v", err))
+			}
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// stop will stop the listener and wait for it to exit
+func (s *signalListener) stop() {
+	signal.Stop(s.signals)
+	close(s.quit)
+	<-s.done
+}
+
+// ListenForSignal will rotate the log file whenever the provided signal is received (E.g. syscall.SIGUSR1)
+// Note: Calling this multiple times with the same signal will not register the signal twice
+func (l *Logger) ListenForSignal(sig os.Signal) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	if l.signals == nil {
+		// Listener does not exist, create it
+		l.signals = &signalListener{
+			signals: make(chan os.Signal, 1),
+			quit:    make(chan struct{}),
+			done:    make(chan struct{}),
+		}
+
+		go l.signals.listen(l)
+	}
+
+	// Register signal with our listener, registering an existing signal is a no-op
+	signal.Notify(l.signals.signals, sig)
+	return
+}
+
+// StopSignalListener will stop listening for all signals registered with ListenForSignal
+func (l *Logger) StopSignalListener() {
+	// Acquire lock
+	l.mu.Lock()
+	s := l.signals
+	l.signals = nil
+	// Release lock before stopping, as the listener may be waiting on the lock to rotate
+	l.mu.Unlock()
+
+	if s != nil {
+		s.stop()
+	}
+}
@@ -0,0 +1,77 @@
+//go:build !windows
+// +build !windows
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestListenForSignal(t *testing.T) {
+	var (
+		l *Logger
+
+		rotated = make(chan string, 2)
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.SetRotationHook(func(oldPath, newPath string) {
+		rotated <- oldPath
+	})
+
+	if err = l.ListenForSignal(syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.ListenForSignal(syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-rotated:
+	case <-time.After(time.Second):
+		t.Fatal("expected rotation on signal")
+	}
+
+	l.StopSignalListener()
+	if err = l.LogString("#2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Ignore the signal so it does not terminate the test process once the listener is stopped
+	signal.Ignore(syscall.SIGUSR1)
+	defer signal.Reset(syscall.SIGUSR1)
+
+	if err = syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-rotated:
+		t.Fatal("unexpected rotation after stopping signal listener")
+	case <-time.After(time.Millisecond * 100):
+	}
+}
@@ -0,0 +1,269 @@
+//go:build go1.21
+// +build go1.21
+
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// NewSlogHandler will return a slog.Handler which writes records to the logger as JSON lines
+// Note: Records are always written in the JSON format, with their attributes (and groups) following the core fields.
+// Records are enabled when their level meets both the logger's level and opts.Level (if set). AddSource and
+// ReplaceAttr are supported, a nil opts uses the default options
+func NewSlogHandler(l *Logger, opts *slog.HandlerOptions) slog.Handler {
+	var h slogHandler
+	h.l = l
+	if opts != nil {
+		h.opts = *opts
+	}
+
+	return &h
+}
+
+// slogHandler is a slog.Handler which writes to a logger
+type slogHandler struct {
+	l    *Logger
+	opts slog.HandlerOptions
+
+	// Groups and attributes added with WithGroup and WithAttrs, in the order they were added
+	goas []groupOrAttrs
+}
+
+// groupOrAttrs is either a group name or a set of attributes
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+// Enabled will return whether or not records of the provided level are written
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.opts.Level != nil && level < h.opts.Level.Level() {
+		return false
+	}
+
+	// Acquire lock
+	h.l.mu.Lock()
+	// Get minimum level while the lock is held
+	min := h.l.level
+	// Release lock
+	h.l.mu.Unlock()
+	return levelFromSlog(level) >= min
+}
+
+// Handle will write the record to the logger
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var s slogState
+	s.h = h
+
+	if h.opts.AddSource && r.PC != 0 {
+		s.appendAttr(slog.Any(slog.SourceKey, newSlogSource(r.PC)))
+	}
+
+	goas := h.goas
+	if r.NumAttrs() == 0 {
+		// Record has no attributes, trailing groups would be empty so they are omitted
+		for len(goas) > 0 && goas[len(goas)-1].group != "" {
+			goas = goas[:len(goas)-1]
+		}
+	}
+
+	for _, goa := range goas {
+		if goa.group != "" {
+			s.openGroup(goa.group)
+			continue
+		}
+
+		for _, a := range goa.attrs {
+			s.appendAttr(a)
+		}
+	}
+
+	r.Attrs(func(a slog.Attr) bool {
+		s.appendAttr(a)
+		return true
+	})
+
+	s.closeGroups()
+	return h.l.logAttrs(levelFromSlog(r.Level), []byte(r.Message), s.buf)
+}
+
+// WithAttrs will return a new handler with the provided attributes
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	return h.withGroupOrAttrs(groupOrAttrs{attrs: attrs})
+}
+
+// WithGroup will return a new handler which nests subsequent attributes within the provided group
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return h.withGroupOrAttrs(groupOrAttrs{group: name})
+}
+
+// withGroupOrAttrs will return a copy of the handler with the provided group or attributes appended
+func (h *slogHandler) withGroupOrAttrs(goa groupOrAttrs) *slogHandler {
+	clone := *h
+	clone.goas = make([]groupOrAttrs, len(h.goas)+1)
+	copy(clone.goas, h.goas)
+	clone.goas[len(h.goas)] = goa
+	return &clone
+}
+
+// slogState encodes the attributes of a single record
+type slogState struct {
+	h *slogHandler
+
+	// Encoded JSON members, each preceded by a comma unless it is the first member of a group
+	buf []byte
+	// Names of the currently opened groups
+	groups []string
+	// Whether or not the next member is the first member of a group
+	first bool
+}
+
+// openGroup will open a nested JSON object for the provided group
+func (s *slogState) openGroup(name string) {
+	s.appendKey(name)
+	s.buf = append(s.buf, '{')
+	s.groups = append(s.groups, name)
+	s.first = true
+}
+
+// closeGroups will close all opened groups
+func (s *slogState) closeGroups() {
+	for range s.groups {
+		s.buf = append(s.buf, '}')
+	}
+
+	s.groups = nil
+}
+
+// appendKey will append a member key, preceded by a comma when it is not the first member of a group
+func (s *slogState) appendKey(key string) {
+	if !s.first {
+		s.buf = append(s.buf, ',')
+	}
+
+	s.first = false
+	s.buf, _ = appendJSONString(s.buf, key)
+	s.buf = append(s.buf, ':')
+}
+
+// appendAttr will append an attribute, following the slog rules for empty attributes and groups
+func (s *slogState) appendAttr(a slog.Attr) {
+	if rep := s.h.opts.ReplaceAttr; rep != nil && a.Value.Kind() != slog.KindGroup {
+		// Replace attribute, groups are not passed to ReplaceAttr
+		a = rep(s.groups, a)
+	}
+
+	// Resolve LogValuers
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		// Empty attributes are ignored
+		return
+	}
+
+	if a.Value.Kind() != slog.KindGroup {
+		s.appendKey(a.Key)
+		s.appendValue(a.Value)
+		return
+	}
+
+	attrs := a.Value.Group()
+	if len(attrs) == 0 {
+		// Empty groups are ignored
+		return
+	}
+
+	if a.Key == "" {
+		// Groups with an empty key are inlined
+		for _, ga := range attrs {
+			s.appendAttr(ga)
+		}
+
+		return
+	}
+
+	s.openGroup(a.Key)
+	for _, ga := range attrs {
+		s.appendAttr(ga)
+	}
+
+	s.buf = append(s.buf, '}')
+	s.groups = s.groups[:len(s.groups)-1]
+	s.first = false
+}
+
+// appendValue will append an attribute value as JSON
+func (s *slogState) appendValue(v slog.Value) {
+	switch v.Kind() {
+	case slog.KindString:
+		s.buf, _ = appendJSONString(s.buf, v.String())
+	case slog.KindInt64:
+		s.buf = strconv.AppendInt(s.buf, v.Int64(), 10)
+	case slog.KindUint64:
+		s.buf = strconv.AppendUint(s.buf, v.Uint64(), 10)
+	case slog.KindFloat64:
+		s.appendAny(v.Float64())
+	case slog.KindBool:
+		s.buf = strconv.AppendBool(s.buf, v.Bool())
+	case slog.KindDuration:
+		// Durations are written in nanoseconds, matching slog.JSONHandler
+		s.buf = strconv.AppendInt(s.buf, int64(v.Duration()), 10)
+	case slog.KindTime:
+		s.buf, _ = appendJSONString(s.buf, v.Time().Format(time.RFC3339Nano))
+	default:
+		s.appendAny(v.Any())
+	}
+}
+
+// appendAny will append an arbitrary value as JSON, falling back to its string representation
+func (s *slogState) appendAny(v interface{}) {
+	if err, ok := v.(error); ok {
+		// Errors are written as their message
+		s.buf, _ = appendJSONString(s.buf, err.Error())
+		return
+	}
+
+	bs, err := json.Marshal(v)
+	if err != nil {
+		s.buf, _ = appendJSONString(s.buf, fmt.Sprintf("%+v", v))
+		return
+	}
+
+	s.buf = append(s.buf, bs...)
+}
+
+// newSlogSource will return the source of the provided program counter
+func newSlogSource(pc uintptr) *slog.Source {
+	frames := runtime.CallersFrames([]uintptr{pc})
+	f, _ := frames.Next()
+	return &slog.Source{Function: f.Function, File: f.File, Line: f.Line}
+}
+
+// levelFromSlog will return the level which corresponds to the provided slog level
+// Note: Levels between the slog levels are rounded down, levels above error are written as errors
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarn
+	default:
+		return LevelError
+	}
+}
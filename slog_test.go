@@ -0,0 +1,160 @@
+//go:build go1.21
+// +build go1.21
+
+package logger
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewSlogHandler(t *testing.T) {
+	var (
+		l *Logger
+
+		records []map[string]interface{}
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetLevel(LevelInfo)
+
+	s := slog.New(NewSlogHandler(l, nil))
+	s.Debug("filtered by the logger level")
+	s.Info("request handled", "method", "GET", "status", 200, "took", time.Millisecond)
+	s.With("service", "api").WithGroup("request").With("id", "abc").Warn("slow request",
+		slog.Group("user", "name", "jane", "admin", false),
+		slog.Group("empty"),
+	)
+	s.WithGroup("unused").Error("request failed", "error", errors.New("connection reset"))
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var record map[string]interface{}
+		if err = json.Unmarshal(sc.Bytes(), &record); err != nil {
+			t.Fatalf("invalid JSON line \"%s\": %v", sc.Text(), err)
+		}
+
+		// Remove the timestamp, which varies between runs
+		delete(record, "ts")
+		records = append(records, record)
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"level":  "INFO",
+			"msg":    "request handled",
+			"method": "GET",
+			"status": float64(200),
+			"took":   float64(time.Millisecond),
+		},
+		{
+			"level":   "WARN",
+			"msg":     "slow request",
+			"service": "api",
+			"request": map[string]interface{}{
+				"id":   "abc",
+				"user": map[string]interface{}{"name": "jane", "admin": false},
+			},
+		},
+		{
+			"level":  "ERROR",
+			"msg":    "request failed",
+			"unused": map[string]interface{}{"error": "connection reset"},
+		},
+	}
+
+	if len(records) != len(expected) {
+		t.Fatalf("invalid number of records, expected %d and received %d", len(expected), len(records))
+	}
+
+	for i, record := range records {
+		if !reflect.DeepEqual(record, expected[i]) {
+			t.Fatalf("invalid record, expected %v and received %v", expected[i], record)
+		}
+	}
+}
+
+func TestNewSlogHandler_options(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewSlogHandler(l, &slog.HandlerOptions{
+		Level:     slog.LevelWarn,
+		AddSource: true,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == "password" {
+				return slog.String(a.Key, "***")
+			}
+
+			return a
+		},
+	})
+
+	if h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Fatal("expected info level to be disabled by the handler options")
+	}
+
+	slog.New(h).Warn("login", "user", "jane", "password", "hunter2")
+
+	var entries []Entry
+	if entries, err = l.Tail(1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 1, len(entries))
+	}
+
+	e := entries[0]
+	if e.Extra["password"] != "***" {
+		t.Fatalf("invalid password, expected \"%s\" and received \"%s\"", "***", e.Extra["password"])
+	}
+
+	if _, ok := e.Extra["source"]; !ok {
+		t.Fatal("expected source to be present")
+	}
+}
@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+)
+
+var (
+	// defaultStackDelimiter is the default delimiter used to separate stack frames
+	defaultStackDelimiter = []byte(" | ")
+
+	// captureStackFn is the function name of captureStack (E.g. github.com/gdbu/logger.captureStack)
+	captureStackFn = pkgPath + ".captureStack"
+)
+
+// captureStack will capture the stack of the current goroutine, condensing each frame to a single line
+func captureStack(delim []byte) (stack []byte) {
+	buf := make([]byte, 4096)
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+
+		// Stack did not fit within our buffer, double the buffer size and try again
+		buf = make([]byte, len(buf)*2)
+	}
+
+	return compactStack(buf, delim)
+}
+
+// compactStack will condense each frame of a stack trace to a single line, separated by the provided delimiter
+// Note: Frames from within this package are omitted
+func compactStack(stack, delim []byte) (out []byte) {
+	lines := bytes.Split(bytes.TrimSpace(stack), newline)
+	// The first line is the goroutine header, each frame following is a function line followed by a location line
+	for i := 1; i+1 < len(lines); i += 2 {
+		fn := lines[i]
+		if isLoggerFrame(string(fn)) {
+			// Frame is from within this package, continue
+			continue
+		}
+
+		if len(out) > 0 {
+			// Append delimiter to separate frames
+			out = append(out, delim...)
+		}
+
+		// Append function, followed by the location of the frame
+		out = append(out, fn...)
+		out = append(out, ' ')
+		out = append(out, bytes.TrimSpace(lines[i+1])...)
+	}
+
+	return
+}
+
+// isLoggerFrame will return whether or not a stack frame function belongs to this package's logging path
+func isLoggerFrame(fn string) bool {
+	return strings.HasPrefix(fn, methodPrefix) || strings.HasPrefix(fn, captureStackFn)
+}
+
+// SetCaptureStack will set the levels which will include a stack trace within their entries
+// Note: Stack frames are appended to the message, separated by the stack delimiter. Calling with no levels disables
+// this feature
+func (l *Logger) SetCaptureStack(levels ...Level) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Reset capture levels
+	l.captureStack = [len(levelBytes)]bool{}
+	for _, level := range levels {
+		if level.isValid() {
+			l.captureStack[level] = true
+		}
+	}
+}
+
+// SetStackDelimiter will set the delimiter used to separate stack frames (defaults to " | ")
+func (l *Logger) SetStackDelimiter(delim []byte) (err error) {
+	// Ensure the delimiter is valid
+	if len(delim) == 0 || bytes.Index(delim, newline) > -1 {
+		return ErrInvalidDelimiter
+	}
+
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set stack delimiter
+	l.stackDelim = append([]byte(nil), delim...)
+	return
+}
+
+// shouldCaptureStack will return whether or not the stack should be captured for a level
+func (l *Logger) shouldCaptureStack(level Level) bool {
+	return level.isValid() && l.captureStack[level]
+}
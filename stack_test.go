@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCaptureStack(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs [][]byte
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetCaptureStack(LevelError)
+	if err = l.Error([]byte("boom")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Info([]byte("fine")); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, append([]byte(nil), log...))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logs) != 2 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 2, len(logs))
+	}
+
+	parts := bytes.Split(logs[0], defaultStackDelimiter)
+	if len(parts) < 2 {
+		t.Fatalf("expected stack to be included: \"%s\"", string(logs[0]))
+	}
+
+	if msg := string(parts[0]); msg != "ERROR@boom" {
+		t.Fatalf("invalid message, expected \"%s\" and received \"%s\"", "ERROR@boom", msg)
+	}
+
+	frame := string(parts[1])
+	if !strings.HasPrefix(frame, pkgPath+".TestCaptureStack(") || !strings.Contains(frame, "stack_test.go:") {
+		t.Fatalf("invalid first frame: \"%s\"", frame)
+	}
+
+	for _, frame := range parts[1:] {
+		if isLoggerFrame(string(frame)) {
+			t.Fatalf("unexpected logger frame: \"%s\"", string(frame))
+		}
+	}
+
+	if log := string(logs[1]); log != "INFO @fine" {
+		t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", "INFO @fine", log)
+	}
+}
@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+const (
+	// messageSizeBuckets is the number of buckets in the message size histogram
+	messageSizeBuckets = 10
+	// histogramBarWidth is the width of the largest bar printed by PrintHistogram
+	histogramBarWidth = 40
+)
+
+// Stats represents a snapshot of logger metrics
+type Stats struct {
+	// Total number of lines written for the lifetime of the logger
+	TotalLinesWritten int64 `json:"totalLinesWritten"`
+	// Total number of bytes written for the lifetime of the logger
+	TotalBytesWritten int64 `json:"totalBytesWritten"`
+	// Total number of rotations for the lifetime of the logger
+	TotalRotations int `json:"totalRotations"`
+	// Time of the last rotation, zero if the logger has not rotated
+	LastRotationTime time.Time `json:"lastRotationTime"`
+	// Number of entries written for each message size, bucket i counts messages of [2^i, 2^(i+1)) bytes
+	// Note: Empty messages are counted in the first bucket, messages of 512 bytes or more in the last bucket
+	MessageSizeHistogram [messageSizeBuckets]int64 `json:"messageSizeHistogram"`
+
+	// Number of bytes written to the current file
+	CurrentFileSize int64 `json:"currentFileSize"`
+	// Number of lines written to the current file
+	CurrentLineCount int `json:"currentLineCount"`
+}
+
+// Stats will return a snapshot of the logger metrics
+func (l *Logger) Stats() (s Stats) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	s = l.stats
+	s.CurrentFileSize = l.size
+	s.CurrentLineCount = l.count
+	return
+}
+
+// PrintHistogram will write the message size histogram as an ASCII bar chart
+func (s Stats) PrintHistogram(w io.Writer) (err error) {
+	var max int64
+	for _, n := range s.MessageSizeHistogram {
+		if n > max {
+			max = n
+		}
+	}
+
+	for i, n := range s.MessageSizeHistogram {
+		var width int64
+		if max > 0 {
+			// Scale bar relative to the largest bucket
+			width = n * histogramBarWidth / max
+		}
+
+		if n > 0 && width == 0 {
+			// Ensure non-empty buckets are visible
+			width = 1
+		}
+
+		if _, err = fmt.Fprintf(w, "%-12s | %-*s %d\n", messageSizeLabel(i), histogramBarWidth,
+			strings.Repeat("#", int(width)), n); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// messageSizeBucket will return the histogram bucket for the provided message size
+func messageSizeBucket(size int) (bucket int) {
+	if bucket = bits.Len(uint(size)) - 1; bucket < 0 {
+		// Message is empty, count within the first bucket
+		return 0
+	}
+
+	if bucket >= messageSizeBuckets {
+		// Message exceeds the largest bucket, cap at the last bucket
+		return messageSizeBuckets - 1
+	}
+
+	return
+}
+
+// messageSizeLabel will return the label of the provided histogram bucket (E.g. [64, 128))
+func messageSizeLabel(bucket int) string {
+	if bucket == messageSizeBuckets-1 {
+		return fmt.Sprintf("[%d, +inf)", 1<<uint(bucket))
+	}
+
+	return fmt.Sprintf("[%d, %d)", 1<<uint(bucket), 1<<uint(bucket+1))
+}
+
+// incrementLevelCount will increment the count of entries written for the provided level
+func (l *Logger) incrementLevelCount(level Level) {
+	if !level.isValid() {
+		// Entry does not have a level, return
+		return
+	}
+
+	l.levelCounts[level].Add(1)
+}
+
+// LevelStats will return the number of entries written for each level, for the lifetime of the logger
+// Note: Only entries which have been written are counted (E.g. entries below the minimum level are not)
+func (l *Logger) LevelStats() (stats map[Level]int64) {
+	stats = make(map[Level]int64, len(l.levelCounts))
+	for i := range l.levelCounts {
+		stats[Level(i)] = l.levelCounts[i].Load()
+	}
+
+	return
+}
+
+// ResetLevelStats will reset the level counts to zero
+func (l *Logger) ResetLevelStats() {
+	for i := range l.levelCounts {
+		l.levelCounts[i].Store(0)
+	}
+}
@@ -0,0 +1,155 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.SetNumLines(2)
+
+	// Each entry is 23 bytes (19 byte timestamp, separator, 2 byte message, newline)
+	for i := 0; i < 3; i++ {
+		if err = l.LogString("#1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := l.Stats()
+	if s.TotalLinesWritten != 3 || s.TotalBytesWritten != 69 {
+		t.Fatalf("invalid totals, received %d lines and %d bytes", s.TotalLinesWritten, s.TotalBytesWritten)
+	}
+
+	if s.TotalRotations != 1 || s.LastRotationTime.IsZero() {
+		t.Fatalf("invalid rotation stats, received %d rotations at %v", s.TotalRotations, s.LastRotationTime)
+	}
+
+	if s.CurrentLineCount != 1 || s.CurrentFileSize != 23 {
+		t.Fatalf("invalid current stats, received %d lines and %d bytes", s.CurrentLineCount, s.CurrentFileSize)
+	}
+}
+
+func TestLevelStats(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// Counts are kept across rotations
+	l.SetNumLines(2)
+
+	logs := []struct {
+		fn func([]byte) error
+		n  int
+	}{
+		{fn: l.Debug, n: 3},
+		{fn: l.Info, n: 2},
+		{fn: l.Error, n: 1},
+		{fn: l.Log, n: 2},
+	}
+
+	for _, log := range logs {
+		for i := 0; i < log.n; i++ {
+			if err = log.fn([]byte("hello world")); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	expected := map[Level]int64{LevelDebug: 3, LevelInfo: 2, LevelWarn: 0, LevelError: 1, LevelFatal: 0}
+	stats := l.LevelStats()
+	if len(stats) != len(expected) {
+		t.Fatalf("invalid number of levels, expected %d and received %d", len(expected), len(stats))
+	}
+
+	for level, n := range expected {
+		if stats[level] != n {
+			t.Fatalf("invalid %s count, expected %d and received %d", level.name(), n, stats[level])
+		}
+	}
+
+	l.ResetLevelStats()
+	for level, n := range l.LevelStats() {
+		if n != 0 {
+			t.Fatalf("invalid %s count, expected %d and received %d", level.name(), 0, n)
+		}
+	}
+}
+
+func TestStats_MessageSizeHistogram(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	for _, size := range []int{1, 100, 1000, 10000} {
+		if err = l.Log(bytes.Repeat([]byte{'a'}, size)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := l.Stats()
+	// 1 byte is within [1, 2), 100 bytes within [64, 128), 1000 and 10000 bytes are capped at the last bucket
+	expected := [messageSizeBuckets]int64{1, 0, 0, 0, 0, 0, 1, 0, 0, 2}
+	if s.MessageSizeHistogram != expected {
+		t.Fatalf("invalid histogram, expected %v and received %v", expected, s.MessageSizeHistogram)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err = s.PrintHistogram(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != messageSizeBuckets {
+		t.Fatalf("invalid number of lines, expected %d and received %d", messageSizeBuckets, len(lines))
+	}
+
+	if line := lines[messageSizeBuckets-1]; !strings.HasPrefix(line, "[512, +inf)") || !strings.HasSuffix(line, " 2") {
+		t.Fatalf("invalid line, received %q", line)
+	}
+
+	if bars := strings.Count(lines[messageSizeBuckets-1], "#"); bars != histogramBarWidth {
+		t.Fatalf("invalid bar width, expected %d and received %d", histogramBarWidth, bars)
+	}
+}
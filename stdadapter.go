@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"log"
+	"regexp"
+)
+
+// stdHeader matches the header the standard library log package writes for its date, time and file flags
+var stdHeader = regexp.MustCompile(`^(\d{4}/\d{2}/\d{2} )?(\d{2}:\d{2}:\d{2}(\.\d{6})? )?(\S+\.go:\d+: )?`)
+
+// NewStdAdapter will return a standard library *log.Logger which writes to the provided logger
+// Note: All flags are cleared so the standard library does not add a timestamp of its own. When stripFlags is set,
+// the header written by any flags set later (E.g. with SetFlags) is stripped before the message is logged. Prefixes
+// set with SetPrefix are kept, as they cannot be distinguished from the message
+func NewStdAdapter(l *Logger, stripFlags bool) *log.Logger {
+	var w stdWriter
+	w.l = l
+	w.stripFlags = stripFlags
+	return log.New(&w, "", 0)
+}
+
+// stdWriter is the writer of a standard library logger created by NewStdAdapter
+type stdWriter struct {
+	l *Logger
+	// Strip the header written by the standard library flags
+	stripFlags bool
+}
+
+// Write will log the provided bytes to the underlying logger
+func (w *stdWriter) Write(p []byte) (n int, err error) {
+	msg := p
+	if w.stripFlags {
+		// Strip the standard library header (if it exists)
+		msg = msg[len(stdHeader.Find(msg)):]
+	}
+
+	if _, err = w.l.Write(msg); err != nil {
+		return
+	}
+
+	// Return the original length of p to satisfy the io.Writer contract
+	return len(p), nil
+}
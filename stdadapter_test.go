@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"log"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewStdAdapter(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs []string
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	std := NewStdAdapter(l, true)
+	if flags := std.Flags(); flags != 0 {
+		t.Fatalf("invalid flags, expected %d and received %d", 0, flags)
+	}
+
+	std.Printf("hello %s", "world")
+
+	// Flags set later are stripped
+	std.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Lshortfile)
+	std.Println("with flags")
+
+	// Flags are kept when stripping is disabled
+	unstripped := NewStdAdapter(l, false)
+	unstripped.SetFlags(log.Lshortfile)
+	unstripped.Print("unstripped")
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, string(log))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"hello world", "with flags", "stdadapter_test.go:LINE: unstripped"}
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	// Replace the line number of the unstripped header
+	logs[2] = regexp.MustCompile(`:\d+:`).ReplaceAllString(logs[2], ":LINE:")
+
+	for i, log := range logs {
+		if log != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], log)
+		}
+	}
+}
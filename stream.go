@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"unicode/utf8"
+)
+
+// appendEvent will append the line as a Server-Sent Event (E.g. "data: <line>\n\n") to the provided buffer
+// Note: Lines which would break the event framing (E.g. containing a carriage return or invalid UTF-8) are sent Base64
+// encoded, preceded by the Base64 marker
+func appendEvent(buf, line []byte) []byte {
+	buf = append(buf, "data: "...)
+	if bytes.IndexByte(line, '\r') == -1 && utf8.Valid(line) {
+		buf = append(buf, line...)
+	} else {
+		buf = append(buf, base64Marker...)
+		n := len(buf)
+		buf = append(buf, make([]byte, base64.StdEncoding.EncodedLen(len(line)))...)
+		base64.StdEncoding.Encode(buf[n:], line)
+	}
+
+	return append(buf, '\n', '\n')
+}
+
+// isMessagePack will return whether or not entries are written as MessagePack
+func (l *Logger) isMessagePack() bool {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	return l.format == formatMessagePack
+}
+
+// ServeHTTP will stream the entries of the current file to the client as Server-Sent Events, allowing Logger to be used
+// as an http.Handler
+// Note: The current file is tailed from the beginning, switching to the next file after each rotation (see
+// FollowLatest), so entries are sent in order and never dropped. Each entry is sent as "data: <line>\n\n" once it has
+// been flushed. MessagePack files are not line-based and cannot be streamed. The stream ends when the client
+// disconnects or the logger is closed
+func (l *Logger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	if l.isMessagePack() {
+		http.Error(w, "streaming is not supported for MessagePack entries", http.StatusNotImplemented)
+		return
+	}
+
+	// Flush buffered entries, so they are included within the stream
+	if err := l.Flush(); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	f, err := newFollower(l, true)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer f.close()
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	buf := make([]byte, 0, 256)
+	// Note: The follower ends once the client has disconnected, the logger has been closed or a write has failed
+	f.run(r.Context(), func(line []byte) (err error) {
+		buf = appendEvent(buf[:0], line)
+		if _, err = w.Write(buf); err != nil {
+			return
+		}
+
+		flusher.Flush()
+		return
+	})
+}
+
+// NewHTTPHandler will return an http.Handler which streams the entries of the provided logger as Server-Sent Events
+func NewHTTPHandler(l *Logger) http.Handler {
+	return l
+}
@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServeHTTP(t *testing.T) {
+	var (
+		l *Logger
+
+		resp *http.Response
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// Entries written before the client connects are streamed from the current file
+	if err = l.LogString("#0"); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		NewHTTPHandler(l).ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp, err = http.DefaultClient.Do(req.WithContext(ctx)); err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("invalid content type, expected \"%s\" and received \"%s\"", "text/event-stream", ct)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entries continue to stream after a rotation
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Info([]byte("#2")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Carriage returns would break the event framing, so the line is Base64 encoded
+	if err = l.LogString("#3\r"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	s := bufio.NewScanner(resp.Body)
+	expected := []string{"#0", "#1", "INFO @#2", "#3\r"}
+	for _, exp := range expected {
+		var line string
+		for line == "" && s.Scan() {
+			line = s.Text()
+		}
+
+		if !strings.HasPrefix(line, "data: ") {
+			t.Fatalf("invalid event: \"%s\"", line)
+		}
+
+		raw := []byte(strings.TrimPrefix(line, "data: "))
+		if bytes.HasPrefix(raw, []byte(base64Marker)) {
+			if raw, err = base64.StdEncoding.DecodeString(string(raw[len(base64Marker):])); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		_, msg, err := parseLine(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(msg) != exp {
+			t.Fatalf("invalid log, expected %q and received %q", exp, msg)
+		}
+	}
+
+	// Disconnect the client, the handler should return
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected handler to return after the client disconnected")
+	}
+}
+
+func TestServeHTTP_messagePack(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = NewWithOptions(testDir, testName, WithMessagePack(true)); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	rec := httptest.NewRecorder()
+	l.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusNotImplemented {
+		t.Fatalf("invalid status code, expected %d and received %d", http.StatusNotImplemented, rec.Code)
+	}
+}
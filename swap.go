@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"os"
+
+	"github.com/hatchify/errors"
+)
+
+// tmpExt is the extension of new log files which have not yet received their first entry (E.g. name.<ts>.log.tmp)
+const tmpExt = ".tmp"
+
+// openFile will open the provided filename as the current file
+// Note: When atomic swap is enabled and the file does not yet exist, the file is opened with the temporary extension
+// and renamed to the provided filename once it receives its first entry
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) openFile(filename string) (err error) {
+	openname := filename
+	if l.atomicSwap {
+		if _, err = os.Stat(filename); os.IsNotExist(err) {
+			// File does not exist, open with temporary extension
+			openname = filename + tmpExt
+		} else if err != nil {
+			return
+		}
+	}
+
+	if l.f, err = os.OpenFile(openname, loggerFlag, l.filePerm); err != nil {
+		return
+	}
+
+	l.filename = filename
+	if openname != filename {
+		// Set temporary filename, this is cleared once the file is renamed
+		l.tmpFilename = openname
+	}
+
+	return
+}
+
+// renameTemp will rename the current temporary file (if set) to its final name
+// Note: Buffered entries are flushed first, so the renamed file always contains its first entry
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) renameTemp() (err error) {
+	if l.tmpFilename == "" {
+		// Current file is not a temporary file, return
+		return
+	}
+
+	if err = l.flush(); err != nil {
+		return
+	}
+
+	if err = os.Rename(l.tmpFilename, l.filename); err != nil {
+		return
+	}
+
+	l.tmpFilename = ""
+	if l.latestSymlink {
+		// Latest symlink is enabled, point it to the renamed file
+		return l.updateLatest()
+	}
+
+	return
+}
+
+// SetAtomicSwap will set whether or not new files are opened with a temporary extension until their first entry
+// Note: When enabled, new files are opened as name.<ts>.log.tmp and atomically renamed to name.<ts>.log once their
+// first entry has been written, so observers of the directory never see an empty new file. Files which are rotated
+// before receiving an entry are removed without being renamed. This takes effect for the next file opened
+func (l *Logger) SetAtomicSwap(atomicSwap bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.atomicSwap = atomicSwap
+	return
+}
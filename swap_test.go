@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSetAtomicSwap(t *testing.T) {
+	var (
+		l *Logger
+
+		files []logFile
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetAtomicSwap(true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tmp := l.filename + tmpExt
+	if _, err = os.Stat(tmp); err != nil {
+		t.Fatal(err)
+	}
+
+	if files, err = listFiles(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 0 {
+		t.Fatalf("invalid number of files, expected %d and received %d", 0, len(files))
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = os.Stat(tmp); !os.IsNotExist(err) {
+		t.Fatalf("invalid error, expected %v and received %v", os.ErrNotExist, err)
+	}
+
+	if files, err = listFiles(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("invalid number of files, expected %d and received %d", 1, len(files))
+	}
+
+	if files[0].filename != l.filename {
+		t.Fatalf("invalid filename, expected \"%s\" and received \"%s\"", l.filename, files[0].filename)
+	}
+
+	if isEmptyFile(l.f) {
+		t.Fatal("expected renamed file to contain the first entry")
+	}
+}
+
+func TestSetAtomicSwap_empty(t *testing.T) {
+	var (
+		l *Logger
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetAtomicSwap(true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tmp := l.filename + tmpExt
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = os.Stat(tmp); !os.IsNotExist(err) {
+		t.Fatalf("invalid error, expected %v and received %v", os.ErrNotExist, err)
+	}
+}
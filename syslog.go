@@ -0,0 +1,68 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package logger
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// NewSyslog will return a writer which forwards entries to a syslog daemon (E.g. for use with SetTee or NewMulti)
+// Note: An empty network and addr will connect to the local syslog daemon's unix socket. The returned writer implements
+// io.Closer, which MultiLogger.Close calls when closing its destinations
+func NewSyslog(network, addr string, priority syslog.Priority, tag string) (w io.Writer, err error) {
+	var s syslogWriter
+	if s.w, err = syslog.Dial(network, addr, priority, tag); err != nil {
+		return
+	}
+
+	return &s, nil
+}
+
+// syslogWriter forwards log lines to syslog with the priority of their level
+type syslogWriter struct {
+	w *syslog.Writer
+}
+
+// Write will forward each line within bs to syslog
+// Note: The timestamp and level are stripped as syslog includes its own timestamp and the level is mapped to the
+// syslog severity. Lines which cannot be parsed are forwarded as is with the writer's default priority
+func (s *syslogWriter) Write(bs []byte) (n int, err error) {
+	return writeLines(bs, s.writeLine)
+}
+
+// writeLine will forward a single log line to syslog
+func (s *syslogWriter) writeLine(line []byte) (err error) {
+	e, err := parseEntry(line)
+	if err != nil {
+		// Line is not a log entry, forward line with the default priority
+		_, err = s.w.Write(line)
+		return
+	}
+
+	level, ok := levelFromName(e.Level)
+	if !ok {
+		// Entry does not have a level, forward message with the default priority
+		_, err = s.w.Write([]byte(e.Message))
+		return
+	}
+
+	switch level {
+	case LevelDebug:
+		return s.w.Debug(e.Message)
+	case LevelInfo:
+		return s.w.Info(e.Message)
+	case LevelWarn:
+		return s.w.Warning(e.Message)
+	case LevelError:
+		return s.w.Err(e.Message)
+	default:
+		return s.w.Crit(e.Message)
+	}
+}
+
+// Close will close the connection to the syslog daemon
+func (s *syslogWriter) Close() error {
+	return s.w.Close()
+}
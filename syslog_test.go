@@ -0,0 +1,107 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log/syslog"
+	"net"
+	"os"
+	"path"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewSyslog(t *testing.T) {
+	var (
+		l    *Logger
+		w    io.Writer
+		conn *net.UnixConn
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	// Start a fake syslog server
+	addr := path.Join(testDir, "syslog.sock")
+	if conn, err = net.ListenUnixgram("unixgram", &net.UnixAddr{Name: addr, Net: "unixgram"}); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if w, err = NewSyslog("unixgram", addr, syslog.LOG_NOTICE|syslog.LOG_LOCAL0, "testing"); err != nil {
+		t.Fatal(err)
+	}
+	defer w.(io.Closer).Close()
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetTee(w); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("no level"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Debug([]byte("debug message")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Warn([]byte("warn message")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Error([]byte("error message")); err != nil {
+		t.Fatal(err)
+	}
+
+	type testcase struct {
+		priority syslog.Priority
+		msg      string
+	}
+
+	tcs := []testcase{
+		{priority: syslog.LOG_NOTICE | syslog.LOG_LOCAL0, msg: "no level"},
+		{priority: syslog.LOG_DEBUG | syslog.LOG_LOCAL0, msg: "debug message"},
+		{priority: syslog.LOG_WARNING | syslog.LOG_LOCAL0, msg: "warn message"},
+		{priority: syslog.LOG_ERR | syslog.LOG_LOCAL0, msg: "error message"},
+	}
+
+	// RFC3164 message format of <PRI>TIMESTAMP TAG[PID]: MSG
+	rfc3164 := regexp.MustCompile(`^<(\d+)>[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2} testing\[\d+\]: (.*)\n?$`)
+	buf := make([]byte, 1024)
+	for _, tc := range tcs {
+		if err = conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+			t.Fatal(err)
+		}
+
+		var n int
+		if n, err = conn.Read(buf); err != nil {
+			t.Fatal(err)
+		}
+
+		matches := rfc3164.FindStringSubmatch(string(buf[:n]))
+		if matches == nil {
+			t.Fatalf("invalid syslog message, received \"%s\"", buf[:n])
+		}
+
+		if priority := fmt.Sprintf("%d", tc.priority); matches[1] != priority {
+			t.Fatalf("invalid priority, expected %s and received %s", priority, matches[1])
+		}
+
+		if matches[2] != tc.msg {
+			t.Fatalf("invalid message, expected \"%s\" and received \"%s\"", tc.msg, matches[2])
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/hatchify/errors"
+)
+
+// tailChunkSize is the size of the chunks read from the end of a file by Tail
+const tailChunkSize = 4096
+
+// Tail will return the last n entries of the current log file
+// Note: The file is read backwards in fixed-size chunks, so only the end of the file is read into memory
+func (l *Logger) Tail(n int) (entries []Entry, err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Release lock
+		l.mu.Unlock()
+		// Instance of logger has been closed, return
+		return nil, errors.ErrIsClosed
+	}
+
+	if l.discard || n <= 0 {
+		// Release lock
+		l.mu.Unlock()
+		// Nothing to read, return
+		return
+	}
+
+	// Flush buffered entries so they are visible to the reader
+	if err = l.flush(); err != nil {
+		// Release lock
+		l.mu.Unlock()
+		return
+	}
+
+	filename := l.filename
+	if l.tmpFilename != "" {
+		// Current file has not been moved into place yet
+		filename = l.tmpFilename
+	}
+
+	// Release lock, the file is read without holding the lock
+	l.mu.Unlock()
+
+	var lines [][]byte
+	if lines, err = tailLines(filename, n); err != nil {
+		return
+	}
+
+	entries = make([]Entry, 0, len(lines))
+	for _, line := range lines {
+		var e Entry
+		if e, err = parseEntry(line); err != nil {
+			return
+		}
+
+		entries = append(entries, e)
+	}
+
+	return
+}
+
+// tailLines will return the last n lines of a file
+func tailLines(filename string, n int) (lines [][]byte, err error) {
+	var f *os.File
+	if f, err = os.Open(filename); err != nil {
+		return
+	}
+	defer f.Close()
+
+	var info os.FileInfo
+	if info, err = f.Stat(); err != nil {
+		return
+	}
+
+	var (
+		chunks   [][]byte
+		newlines int
+	)
+
+	// Read chunks from the end of the file until n+1 newlines have been found. The trailing newline of the last line
+	// is included, so the extra newline ensures the first of the n lines is complete
+	offset := info.Size()
+	for offset > 0 && newlines <= n {
+		size := int64(tailChunkSize)
+		if offset < size {
+			size = offset
+		}
+
+		offset -= size
+		chunk := make([]byte, size)
+		if _, err = f.ReadAt(chunk, offset); err != nil && err != io.EOF {
+			return
+		}
+
+		err = nil
+		newlines += bytes.Count(chunk, newline)
+		chunks = append(chunks, chunk)
+	}
+
+	// Chunks were read in reverse, join them in file order
+	for i, j := 0, len(chunks)-1; i < j; i, j = i+1, j-1 {
+		chunks[i], chunks[j] = chunks[j], chunks[i]
+	}
+
+	buf := bytes.TrimSuffix(bytes.Join(chunks, nil), newline)
+	if len(buf) == 0 {
+		// File is empty, return
+		return
+	}
+
+	if lines = bytes.Split(buf, newline); len(lines) > n {
+		// Trim leading lines, this includes the partial line of the first chunk
+		lines = lines[len(lines)-n:]
+	}
+
+	return
+}
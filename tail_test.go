@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hatchify/errors"
+)
+
+func TestTail(t *testing.T) {
+	var (
+		l *Logger
+
+		entries []Entry
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write enough entries to span multiple chunks
+	total := 1000
+	for i := 0; i < total; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type testcase struct {
+		n        int
+		expected int
+	}
+
+	tcs := []testcase{
+		{n: 0, expected: 0},
+		{n: 1, expected: 1},
+		{n: 10, expected: 10},
+		{n: 500, expected: 500},
+		{n: total, expected: total},
+		{n: total * 2, expected: total},
+	}
+
+	for _, tc := range tcs {
+		if entries, err = l.Tail(tc.n); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(entries) != tc.expected {
+			t.Fatalf("invalid number of entries, expected %d and received %d", tc.expected, len(entries))
+		}
+
+		for i, e := range entries {
+			expected := fmt.Sprintf("#%d", total-tc.expected+i+1)
+			if e.Message != expected {
+				t.Fatalf("invalid message, expected \"%s\" and received \"%s\"", expected, e.Message)
+			}
+		}
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = l.Tail(10); err != errors.ErrIsClosed {
+		t.Fatalf("invalid error, expected %v and received %v", errors.ErrIsClosed, err)
+	}
+}
+
+func TestTail_empty(t *testing.T) {
+	var (
+		l *Logger
+
+		entries []Entry
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if entries, err = l.Tail(10); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 0 {
+		t.Fatalf("invalid number of entries, expected %d and received %d", 0, len(entries))
+	}
+}
@@ -0,0 +1,194 @@
+package logger
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+// defaultTCPMaxBuffered is the default number of entries buffered while a TCP writer is disconnected
+const defaultTCPMaxBuffered = 1000
+
+// NewTCPWriter will return a writer which streams entries over a persistent TCP connection (E.g. to Fluentd or
+// Logstash), for use with SetTee or NewMulti
+// Note: An error is returned if the initial connection fails. If the connection is lost, entries are buffered in
+// memory while reconnecting with reconnectDelay between attempts
+func NewTCPWriter(addr string, reconnectDelay time.Duration) (wp *TCPWriter, err error) {
+	var w TCPWriter
+	if w.conn, err = net.Dial("tcp", addr); err != nil {
+		return
+	}
+
+	w.addr = addr
+	w.reconnectDelay = reconnectDelay
+	w.maxBuffered = defaultTCPMaxBuffered
+	w.done = make(chan struct{})
+	return &w, nil
+}
+
+// TCPWriter streams log entries over a TCP connection
+type TCPWriter struct {
+	mu sync.Mutex
+
+	addr           string
+	reconnectDelay time.Duration
+
+	// Current connection, nil while disconnected
+	conn net.Conn
+	// Entries written while disconnected
+	buffered [][]byte
+	// Maximum number of buffered entries
+	maxBuffered int
+	// Called when an entry is dropped
+	onError ErrorFn
+
+	// Closed when the writer is closed, stops the reconnect loop
+	done chan struct{}
+	// Closed state
+	closed bool
+}
+
+// Write will write the entries within bs to the connection, entries are buffered while disconnected
+// Note: Buffering entries is not an error, dropped entries are passed to the error handler instead
+func (w *TCPWriter) Write(bs []byte) (n int, err error) {
+	// Acquire lock
+	w.mu.Lock()
+	// Defer the release of our lock
+	defer w.mu.Unlock()
+
+	if w.closed {
+		// Writer has been closed, return
+		return 0, errors.ErrIsClosed
+	}
+
+	if w.conn != nil {
+		if _, err = w.conn.Write(bs); err == nil {
+			return len(bs), nil
+		}
+
+		// Connection has been lost, close connection and begin reconnecting
+		w.conn.Close()
+		w.conn = nil
+		go w.reconnectLoop()
+	}
+
+	if len(w.buffered) >= w.maxBuffered {
+		// Buffer is full, drop entry
+		w.errorHandler()(ErrTCPBufferFull)
+		return len(bs), nil
+	}
+
+	// Copy entry, as the caller may re-use the provided slice once we return
+	w.buffered = append(w.buffered, append([]byte(nil), bs...))
+	return len(bs), nil
+}
+
+// SetMaxBuffered will set the maximum number of entries buffered while disconnected (defaults to 1000)
+func (w *TCPWriter) SetMaxBuffered(n int) {
+	// Acquire lock
+	w.mu.Lock()
+	// Defer the release of our lock
+	defer w.mu.Unlock()
+	w.maxBuffered = n
+}
+
+// SetErrorHandler will set the func called when an entry is dropped (defaults to printing the error to stdout)
+// Note: The handler is called while the writer is writing (E.g. while the logger is writing to its tee), so it must
+// not write to the same logger or writer
+func (w *TCPWriter) SetErrorHandler(fn ErrorFn) {
+	// Acquire lock
+	w.mu.Lock()
+	// Defer the release of our lock
+	defer w.mu.Unlock()
+	w.onError = fn
+}
+
+// Close will close the connection, buffered entries are dropped
+func (w *TCPWriter) Close() (err error) {
+	// Acquire lock
+	w.mu.Lock()
+	// Defer the release of our lock
+	defer w.mu.Unlock()
+
+	if w.closed {
+		// Writer has already been closed, return
+		return errors.ErrIsClosed
+	}
+
+	w.closed = true
+	close(w.done)
+	w.buffered = nil
+
+	if w.conn == nil {
+		// Writer is disconnected, return
+		return
+	}
+
+	err = w.conn.Close()
+	w.conn = nil
+	return
+}
+
+// reconnectLoop will attempt to reconnect until it succeeds or the writer is closed
+func (w *TCPWriter) reconnectLoop() {
+	for {
+		select {
+		case <-time.After(w.reconnectDelay):
+		case <-w.done:
+			return
+		}
+
+		if w.reconnect() {
+			return
+		}
+	}
+}
+
+// reconnect will attempt to reconnect and write the buffered entries, returning true once connected (or closed)
+func (w *TCPWriter) reconnect() (ok bool) {
+	conn, err := net.Dial("tcp", w.addr)
+	if err != nil {
+		return false
+	}
+
+	// Acquire lock
+	w.mu.Lock()
+	// Defer the release of our lock
+	defer w.mu.Unlock()
+
+	if w.closed {
+		// Writer was closed while connecting, return
+		conn.Close()
+		return true
+	}
+
+	for len(w.buffered) > 0 {
+		if _, err = conn.Write(w.buffered[0]); err != nil {
+			// Connection was lost while writing the buffered entries, try again
+			conn.Close()
+			return false
+		}
+
+		w.buffered = w.buffered[1:]
+	}
+
+	w.buffered = nil
+	w.conn = conn
+	return true
+}
+
+// errorHandler will return the error handler, or a default handler if one is not set
+// Note: This function expects the lock to be held by the caller
+func (w *TCPWriter) errorHandler() ErrorFn {
+	if w.onError != nil {
+		return w.onError
+	}
+
+	addr := w.addr
+	return func(err error) {
+		fmt.Printf("logger :: tcp :: %s :: %v\n", addr, err)
+	}
+}
@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewTCPWriter(t *testing.T) {
+	var (
+		l  *Logger
+		w  *TCPWriter
+		ln net.Listener
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if ln, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	if w, err = NewTCPWriter(ln.Addr().String(), time.Millisecond*10); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetTee(w); err != nil {
+		t.Fatal(err)
+	}
+
+	var conn net.Conn
+	if conn, err = ln.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = expectTCPLines(conn, "#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a lost connection, entries are buffered until the writer reconnects
+	w.mu.Lock()
+	w.conn.Close()
+	w.mu.Unlock()
+
+	for i := 2; i <= 3; i++ {
+		if err = l.LogString(fmt.Sprintf("#%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if conn, err = ln.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err = expectTCPLines(conn, "#2", "#3"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#4"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = expectTCPLines(conn, "#4"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTCPWriter_bufferFull(t *testing.T) {
+	var (
+		w  *TCPWriter
+		ln net.Listener
+
+		mu   sync.Mutex
+		errs []error
+
+		err error
+	)
+
+	if ln, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+
+	addr := ln.Addr().String()
+	if w, err = NewTCPWriter(addr, time.Millisecond*10); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	w.SetMaxBuffered(2)
+	w.SetErrorHandler(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	})
+
+	// Simulate a lost connection with the listener unavailable
+	ln.Close()
+	w.mu.Lock()
+	w.conn.Close()
+	w.mu.Unlock()
+
+	for i := 1; i <= 5; i++ {
+		if _, err = w.Write([]byte(fmt.Sprintf("#%d\n", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mu.Lock()
+	dropped := len(errs)
+	mu.Unlock()
+
+	if dropped != 3 {
+		t.Fatalf("invalid number of dropped entries, expected %d and received %d", 3, dropped)
+	}
+
+	if ln, err = net.Listen("tcp", addr); err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	var conn net.Conn
+	if conn, err = ln.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err = expectTCPLines(conn, "#1", "#2"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// expectTCPLines will read lines from the connection and ensure each line ends with the expected suffix
+func expectTCPLines(conn net.Conn, expected ...string) (err error) {
+	if err = conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+		return
+	}
+
+	r := bufio.NewReader(conn)
+	for _, suffix := range expected {
+		var line string
+		if line, err = r.ReadString('\n'); err != nil {
+			return
+		}
+
+		if line = strings.TrimSuffix(line, "\n"); !strings.HasSuffix(line, suffix) {
+			return fmt.Errorf("invalid line, expected suffix of \"%s\" and received \"%s\"", suffix, line)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"io"
+
+	"github.com/hatchify/errors"
+)
+
+// writeTee will mirror a formatted entry to the tee writer (if set)
+// Note: Tee errors are passed to the error handler once the lock is released, rather than returned
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) writeTee(bs []byte) {
+	if l.tee == nil {
+		// Tee is not set, return
+		return
+	}
+
+	if _, err := l.tee.Write(bs); err != nil {
+		l.addPending(newErrorCall(l.errorHandler(), err))
+	}
+}
+
+// SetTee will set a writer which receives a copy of every entry written to the log file (E.g. os.Stderr)
+// Note: Entries are mirrored after they are written to the file, a nil writer disables the tee
+func (l *Logger) SetTee(w io.Writer) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	l.tee = w
+	return
+}
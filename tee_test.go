@@ -0,0 +1,108 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestTee(t *testing.T) {
+	var (
+		l *Logger
+
+		buf bytes.Buffer
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetTee(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.Info([]byte("#2")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetTee(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#3"); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), newline), newline)
+	expected := []string{"#1", "INFO @#2"}
+	if len(lines) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(lines))
+	}
+
+	for i, line := range lines {
+		_, msg, err := parseLine(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(msg) != expected[i] {
+			t.Fatalf("invalid log, expected \"%s\" and received \"%s\"", expected[i], msg)
+		}
+	}
+}
+
+func TestTee_error(t *testing.T) {
+	var (
+		l *Logger
+
+		errs []error
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	l.SetErrorHandler(func(err error) {
+		errs = append(errs, err)
+	})
+
+	if err = l.SetTee(failingWriter{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(errs) != 1 {
+		t.Fatalf("invalid number of errors, expected %d and received %d", 1, len(errs))
+	}
+
+	if errs[0] != errTestWrite {
+		t.Fatalf("invalid error, expected %v and received %v", errTestWrite, errs[0])
+	}
+
+	if l.Stats().TotalLinesWritten != 1 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 1, l.Stats().TotalLinesWritten)
+	}
+}
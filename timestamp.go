@@ -0,0 +1,43 @@
+package logger
+
+import "time"
+
+// appendTimestamp will append the current timestamp to the provided buffer using the configured format
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) appendTimestamp(buf []byte) []byte {
+	switch {
+	case l.tsFunc != nil:
+		// Timestamp func is set, append the result
+		return append(buf, l.tsFunc()...)
+	case l.tsFormat != "":
+		// Timestamp format is set, append the formatted current time
+		return time.Now().AppendFormat(buf, l.tsFormat)
+
+	default:
+		// Append unix timestamp
+		return appendUnixTimestamp(buf)
+	}
+}
+
+// SetTimestampFormat will set the time layout used for entry timestamps (E.g. time.RFC3339Nano)
+// Note: An empty format will reset to the default unix timestamp (in nanoseconds). This only applies to the text
+// format, and Reader expects the default timestamp format
+func (l *Logger) SetTimestampFormat(format string) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set timestamp format
+	l.tsFormat = format
+}
+
+// SetTimestampFunc will set the function used to produce entry timestamps
+// Note: This takes precedence over the timestamp format, a nil fn will clear the function
+func (l *Logger) SetTimestampFunc(fn TimestampFn) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+	// Set timestamp func
+	l.tsFunc = fn
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestTimestampFormat(t *testing.T) {
+	var (
+		l *Logger
+		f *os.File
+
+		lines [][]byte
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetTimestampFormat(time.RFC3339Nano)
+	if err = l.LogString("#1"); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetTimestampFunc(func() []byte {
+		return []byte("custom")
+	})
+
+	if err = l.LogString("#2"); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetTimestampFunc(nil)
+	l.SetTimestampFormat("")
+	if err = l.LogString("#3"); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if f, err = os.Open(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		lines = append(lines, append([]byte(nil), s.Bytes()...))
+	}
+
+	if len(lines) != 3 {
+		t.Fatalf("invalid number of lines, expected %d and received %d", 3, len(lines))
+	}
+
+	parts := bytes.SplitN(lines[0], []byte("@"), 2)
+	if _, err = time.Parse(time.RFC3339Nano, string(parts[0])); err != nil {
+		t.Fatalf("invalid timestamp: %v", err)
+	}
+
+	if line := string(lines[1]); line != "custom@#2" {
+		t.Fatalf("invalid line, expected \"%s\" and received \"%s\"", "custom@#2", line)
+	}
+
+	if _, _, err = parseLine(lines[2]); err != nil {
+		t.Fatalf("invalid default timestamp: %v", err)
+	}
+}
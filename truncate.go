@@ -0,0 +1,41 @@
+package logger
+
+import "bytes"
+
+// defaultTruncationMarker is the default suffix appended to truncated messages
+const defaultTruncationMarker = "...[TRUNCATED]"
+
+// truncateMessage will truncate the message to the max line length and append the truncation marker
+// Note: This is called before the lock is acquired (and before the newline check), so a newline beyond the limit is
+// truncated away rather than rejected. The caller's message is never modified
+func (l *Logger) truncateMessage(msg []byte) []byte {
+	max := l.maxLineLength.Load()
+	if max <= 0 || int64(len(msg)) <= max {
+		// Limit is not set or message is within the limit, return
+		return msg
+	}
+
+	marker := l.truncationMarker.Load()
+	out := make([]byte, 0, max+int64(len(marker)))
+	out = append(out, msg[:max]...)
+	return append(out, marker...)
+}
+
+// SetMaxLineLength will set the maximum number of message bytes, longer messages are truncated to n bytes followed by
+// the truncation marker
+// Note: The limit applies to the message only (not the timestamp, level or other metadata). A limit of zero is
+// unlimited (default)
+func (l *Logger) SetMaxLineLength(n int) {
+	l.maxLineLength.Store(int64(n))
+}
+
+// SetTruncationMarker will set the suffix appended to truncated messages (defaults to "...[TRUNCATED]")
+func (l *Logger) SetTruncationMarker(marker []byte) (err error) {
+	// Ensure the marker is valid
+	if bytes.Index(marker, newline) > -1 {
+		return ErrInvalidTruncationMarker
+	}
+
+	l.truncationMarker.Store(string(marker))
+	return
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSetMaxLineLength(t *testing.T) {
+	var (
+		l *Logger
+		r *Reader
+
+		logs [][]byte
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+
+	l.SetMaxLineLength(1024)
+
+	// Newline beyond the limit is truncated away rather than rejected
+	msg := bytes.Repeat([]byte("a"), 10*1024*1024)
+	msg[len(msg)-1] = '\n'
+	if err = l.Log(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogString("short"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.SetTruncationMarker([]byte("bad\nmarker")); err != ErrInvalidTruncationMarker {
+		t.Fatalf("invalid error, expected %v and received %v", ErrInvalidTruncationMarker, err)
+	}
+
+	if err = l.SetTruncationMarker([]byte("<cut>")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = l.LogMany([][]byte{msg}); err != nil {
+		t.Fatal(err)
+	}
+
+	if msg[len(msg)-1] != '\n' {
+		t.Fatal("caller's message was modified")
+	}
+
+	filename := l.f.Name()
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, err = NewReader(filename); err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err = r.ForEach(0, func(ts time.Time, log []byte) (err error) {
+		logs = append(logs, append([]byte(nil), log...))
+		return
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		string(msg[:1024]) + defaultTruncationMarker,
+		"short",
+		string(msg[:1024]) + "<cut>",
+	}
+
+	if len(logs) != len(expected) {
+		t.Fatalf("invalid number of lines, expected %d and received %d", len(expected), len(logs))
+	}
+
+	for i, log := range logs {
+		if string(log) != expected[i] {
+			t.Fatalf("invalid log length, expected %d and received %d", len(expected[i]), len(log))
+		}
+	}
+}
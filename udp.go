@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/hatchify/errors"
+)
+
+// maxUDPDatagram is the maximum payload of a UDP datagram over IPv4
+const maxUDPDatagram = 65507
+
+// NewUDPWriter will return a writer which sends each entry as a single UDP datagram (E.g. to a syslog-compatible
+// listener), for use with SetTee or NewMulti
+// Note: The address is resolved once. Datagrams are sent without a trailing newline, entries which exceed the maximum
+// datagram size are truncated and end with the truncation marker
+func NewUDPWriter(addr string) (wp *UDPWriter, err error) {
+	var raddr *net.UDPAddr
+	if raddr, err = net.ResolveUDPAddr("udp", addr); err != nil {
+		return
+	}
+
+	var w UDPWriter
+	if w.conn, err = net.DialUDP("udp", nil, raddr); err != nil {
+		return
+	}
+
+	w.addr = addr
+	return &w, nil
+}
+
+// UDPWriter sends log entries as UDP datagrams
+type UDPWriter struct {
+	mu sync.Mutex
+
+	addr string
+	conn *net.UDPConn
+
+	// Called when an entry cannot be sent
+	onError ErrorFn
+	// Closed state
+	closed bool
+}
+
+// Write will send each entry within bs as a datagram
+// Note: UDP is fire-and-forget, so entries which cannot be sent are passed to the error handler rather than returned
+func (w *UDPWriter) Write(bs []byte) (n int, err error) {
+	// Acquire lock
+	w.mu.Lock()
+	// Defer the release of our lock
+	defer w.mu.Unlock()
+
+	if w.closed {
+		// Writer has been closed, return
+		return 0, errors.ErrIsClosed
+	}
+
+	return writeLines(bs, func(line []byte) error {
+		if _, err := w.conn.Write(truncateDatagram(line)); err != nil {
+			w.errorHandler()(err)
+		}
+
+		return nil
+	})
+}
+
+// truncateDatagram will truncate the provided line to the maximum datagram size, followed by the truncation marker
+func truncateDatagram(line []byte) []byte {
+	if len(line) <= maxUDPDatagram {
+		return line
+	}
+
+	out := make([]byte, 0, maxUDPDatagram)
+	out = append(out, line[:maxUDPDatagram-len(defaultTruncationMarker)]...)
+	return append(out, defaultTruncationMarker...)
+}
+
+// SetErrorHandler will set the func called when an entry cannot be sent (defaults to printing the error to stdout)
+// Note: The handler is called while the writer is writing (E.g. while the logger is writing to its tee), so it must
+// not write to the same logger or writer
+func (w *UDPWriter) SetErrorHandler(fn ErrorFn) {
+	// Acquire lock
+	w.mu.Lock()
+	// Defer the release of our lock
+	defer w.mu.Unlock()
+	w.onError = fn
+}
+
+// Close will close the socket
+func (w *UDPWriter) Close() (err error) {
+	// Acquire lock
+	w.mu.Lock()
+	// Defer the release of our lock
+	defer w.mu.Unlock()
+
+	if w.closed {
+		// Writer has already been closed, return
+		return errors.ErrIsClosed
+	}
+
+	w.closed = true
+	return w.conn.Close()
+}
+
+// errorHandler will return the error handler, or a default handler if one is not set
+// Note: This function expects the lock to be held by the caller
+func (w *UDPWriter) errorHandler() ErrorFn {
+	if w.onError != nil {
+		return w.onError
+	}
+
+	addr := w.addr
+	return func(err error) {
+		fmt.Printf("logger :: udp :: %s :: %v\n", addr, err)
+	}
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewUDPWriter(t *testing.T) {
+	var (
+		l    *Logger
+		w    *UDPWriter
+		conn net.PacketConn
+
+		err error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if conn, err = net.ListenPacket("udp", "127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if w, err = NewUDPWriter(conn.LocalAddr().String()); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	w.SetErrorHandler(func(err error) {
+		t.Error(err)
+	})
+
+	if l, err = New(testDir, testName); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err = l.SetTee(w); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, msg := range []string{"#1", "#2"} {
+		if err = l.LogString(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, expected := range []string{"#1", "#2"} {
+		var datagram string
+		if datagram, err = testReadDatagram(conn); err != nil {
+			t.Fatal(err)
+		}
+
+		// Each datagram is a complete entry without a trailing newline
+		if !strings.HasSuffix(datagram, "@"+expected) {
+			t.Fatalf("invalid datagram, expected suffix %q and received %q", "@"+expected, datagram)
+		}
+	}
+
+	// Entries which exceed the maximum datagram size are truncated
+	if _, err = w.Write(append(bytes.Repeat([]byte("a"), maxUDPDatagram+100), '\n')); err != nil {
+		t.Fatal(err)
+	}
+
+	var datagram string
+	if datagram, err = testReadDatagram(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(datagram) != maxUDPDatagram {
+		t.Fatalf("invalid datagram length, expected %d and received %d", maxUDPDatagram, len(datagram))
+	}
+
+	if !strings.HasSuffix(datagram, defaultTruncationMarker) {
+		t.Fatalf("invalid datagram, expected suffix %q", defaultTruncationMarker)
+	}
+}
+
+func testReadDatagram(conn net.PacketConn) (datagram string, err error) {
+	if err = conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+		return
+	}
+
+	buf := make([]byte, maxUDPDatagram+1)
+	var n int
+	if n, _, err = conn.ReadFrom(buf); err != nil {
+		return
+	}
+
+	return string(buf[:n]), nil
+}
@@ -2,27 +2,68 @@ package logger
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 )
 
-// getTimestamp will get a unix timestamp as a string
-func getTimestamp() (ts string) {
+// appendUnixTimestamp will append the current unix timestamp (in nanoseconds) to the provided buffer
+func appendUnixTimestamp(buf []byte) []byte {
 	// Current unix timestamp
 	now := time.Now().UnixNano()
-	// Format timestamp to a string and return
-	return strconv.FormatInt(now, 10)
+	// Format timestamp and append to buffer
+	return strconv.AppendInt(buf, now, 10)
 }
 
-// getTimestampBytes will get a unix timestamp as a byteslice
-func getTimestampBytes() (ts []byte) {
-	// Convert string timestamp to byteslice and return
-	return []byte(getTimestamp())
+// isEmptyFile will return whether or not a file is empty
+func isEmptyFile(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		// We cannot determine the file size, assume the file has contents
+		return false
+	}
+
+	return info.Size() == 0
+}
+
+// fileExists will return whether or not a file exists
+// Note: Files which cannot be checked (E.g. due to permissions) are assumed to exist
+func fileExists(filename string) bool {
+	_, err := os.Lstat(filename)
+	return !os.IsNotExist(err)
+}
+
+// releaseBuffer will reset a buffer and return it to the buffer pool
+func releaseBuffer(buf *bytes.Buffer) {
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// writeLines will call fn for each non-empty line within bs, returning len(bs) on success
+func writeLines(bs []byte, fn func(line []byte) error) (n int, err error) {
+	for _, line := range bytes.Split(bytes.TrimSuffix(bs, newline), newline) {
+		if len(line) == 0 {
+			continue
+		}
+
+		if err = fn(line); err != nil {
+			return
+		}
+	}
+
+	return len(bs), nil
 }
 
 // parseLine will parse a log line and return it's timestamp and log bytes
 func parseLine(lineBytes []byte) (ts time.Time, log []byte, err error) {
-	separator := bytes.IndexByte(lineBytes, '@')
+	separator := bytes.IndexByte(lineBytes, defaultSeparator)
+	if separator == -1 {
+		// Line does not contain a separator, return
+		err = ErrMissingSeparator
+		return
+	}
+
 	tsStr := string(lineBytes[:separator])
 
 	var tsInt int64
@@ -35,8 +76,62 @@ func parseLine(lineBytes []byte) (ts time.Time, log []byte, err error) {
 	return
 }
 
+const (
+	// formatText is the default format of timestamp@message
+	formatText format = iota
+	// formatJSON is the JSON object per line format
+	formatJSON
+	// formatLogfmt is the logfmt key-value pairs per line format
+	formatLogfmt
+	// formatCSV is the CSV row per line format
+	formatCSV
+	// formatMessagePack is the length-prefixed MessagePack map format
+	formatMessagePack
+	// formatCompact is the raw message per line format, without a timestamp
+	formatCompact
+)
+
+// format represents the format of log entries
+type format uint8
+
 // RotateFn is called during rotations
 type RotateFn func(filename string)
 
+// RotationHook is called after a rotation with the absolute paths of the closed and newly opened files
+type RotationHook func(oldPath, newPath string)
+
+// newRotationHookCall will return a function which calls the rotation hook with absolute paths
+func newRotationHookCall(fn RotationHook, oldFilename, newFilename string) func() {
+	return func() {
+		fn(getAbsPath(oldFilename), getAbsPath(newFilename))
+	}
+}
+
+// getAbsPath will return the absolute representation of a path, falling back to the provided path on error
+func getAbsPath(filename string) (abs string) {
+	var err error
+	if abs, err = filepath.Abs(filename); err != nil {
+		return filename
+	}
+
+	return
+}
+
+// TimestampFn is called to produce the timestamp of an entry
+type TimestampFn func() []byte
+
+// ErrorFn is called when an error occurs outside of a caller's request (E.g. within a background goroutine)
+type ErrorFn func(err error)
+
+// newErrorCall will return a function which calls the error handler with the provided error
+func newErrorCall(fn ErrorFn, err error) func() {
+	return func() {
+		fn(err)
+	}
+}
+
+// Hook is called to transform a message before it is written, returning an error aborts the write
+type Hook func(msg []byte) ([]byte, error)
+
 // Handler is the function used when handling a log line
 type Handler func(ts time.Time, log []byte) error
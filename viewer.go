@@ -45,6 +45,12 @@ func (v *Viewer) ForEach(fn func(key string) (err error)) (err error) {
 			return
 		}
 
+		// Ensure Iterating log is a log file (E.g. not the latest symlink)
+		if _, ok := parseFilename(info.Name(), v.name); !ok {
+			// Not a log file, return
+			return
+		}
+
 		// Pass filepath provided iterating function
 		return fn(filepath)
 	})
@@ -0,0 +1,136 @@
+package logger
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/hatchify/errors"
+)
+
+const (
+	// walExt is the extension of the write-ahead log file
+	walExt = ".wal"
+	// walFlag is the flag used to open the write-ahead log file, writes are synced to disk before returning
+	walFlag = os.O_RDWR | os.O_APPEND | os.O_CREATE | os.O_SYNC
+)
+
+// getWALFilename will return the path of the write-ahead log file (E.g. dir/name.wal)
+func (l *Logger) getWALFilename() string {
+	return path.Join(l.dir, l.name+walExt)
+}
+
+// writeWAL will write a raw entry to the write-ahead log
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) writeWAL(bs []byte) (err error) {
+	if l.w.Available() < len(bs) {
+		// Entry does not fit within the remaining buffer, flush now so that the buffered writer does not flush
+		// entries which are still within the write-ahead log
+		if err = l.flush(); err != nil {
+			return
+		}
+	}
+
+	_, err = l.wal.Write(bs)
+	return
+}
+
+// truncateWAL will truncate the write-ahead log once buffered entries have been flushed
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) truncateWAL() (err error) {
+	if l.wal == nil {
+		// Write-ahead log is not enabled, return
+		return
+	}
+
+	return l.wal.Truncate(0)
+}
+
+// closeWAL will close and remove the write-ahead log
+// Note: This function expects the lock to be held by the caller, and the buffer to have been flushed
+func (l *Logger) closeWAL() (err error) {
+	if l.wal == nil {
+		// Write-ahead log is not enabled, return
+		return
+	}
+
+	filename := l.wal.Name()
+	err = l.wal.Close()
+	l.wal = nil
+
+	if rerr := os.Remove(filename); err == nil {
+		err = rerr
+	}
+
+	return
+}
+
+// replayWAL will write the entries of an existing write-ahead log to the current file and remove the write-ahead log
+// Note: The write-ahead log only contains entries which had not been flushed, so entries are not duplicated
+func (l *Logger) replayWAL() (err error) {
+	filename := l.getWALFilename()
+
+	var bs []byte
+	if bs, err = ioutil.ReadFile(filename); os.IsNotExist(err) {
+		// Write-ahead log does not exist, return
+		return nil
+	} else if err != nil {
+		return
+	}
+
+	if len(bs) > 0 {
+		if _, err = l.w.Write(bs); err != nil {
+			return
+		}
+
+		// Update current counts to include the replayed entries
+		l.count += bytes.Count(bs, newline)
+		l.size += int64(len(bs))
+
+		if err = l.flush(); err != nil {
+			return
+		}
+	}
+
+	return os.Remove(filename)
+}
+
+// SetWALEnabled will set whether or not entries are written to a write-ahead log before being buffered
+// Note: When enabled, each entry is synced to dir/name.wal before being written to the buffer. If the process crashes
+// before the buffer is flushed, the entries are replayed into the current file by the next call to New. This makes
+// the logger crash-safe at the cost of double write overhead
+func (l *Logger) SetWALEnabled(enabled bool) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	if l.discard {
+		// Discard loggers perform no I/O, return
+		return
+	}
+
+	if enabled == (l.wal != nil) {
+		// State is unchanged, return
+		return
+	}
+
+	// Flush buffered entries so the write-ahead log starts empty
+	if err = l.flush(); err != nil {
+		return
+	}
+
+	if !enabled {
+		return l.closeWAL()
+	}
+
+	l.wal, err = os.OpenFile(l.getWALFilename(), walFlag, l.filePerm)
+	return
+}
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"time"
+
+	"github.com/hatchify/errors"
+)
+
+// writeResult represents the result of a write performed by a write goroutine
+type writeResult struct {
+	n   int
+	err error
+}
+
+// timedWrite will call fn, returning ErrWriteTimeout if it does not complete within the write timeout (when set)
+// Note: A write which times out continues in the background and owns the writer until it completes, see awaitStalled.
+// This function expects the lock to be held by the caller
+func (l *Logger) timedWrite(fn func() (int, error)) (n int, err error) {
+	if l.writeTimeout <= 0 {
+		// Write timeout is not set, write directly
+		return fn()
+	}
+
+	timer := time.NewTimer(l.writeTimeout)
+	defer timer.Stop()
+
+	done := make(chan struct{})
+	result := &writeResult{}
+	go func() {
+		result.n, result.err = fn()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return result.n, result.err
+	case <-timer.C:
+		// Write has stalled, mark the logger as degraded until the write completes
+		l.stalled = done
+		return 0, ErrWriteTimeout
+	}
+}
+
+// awaitStalled will wait up to the write timeout for a stalled write (if any) to complete, returning ErrWriteTimeout if
+// it does not
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) awaitStalled() (err error) {
+	if l.stalled == nil {
+		return
+	}
+
+	timer := time.NewTimer(l.writeTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-l.stalled:
+		l.stalled = nil
+		return
+	case <-timer.C:
+		return ErrWriteTimeout
+	}
+}
+
+// waitStalled will wait for a stalled write (if any) to complete
+// Note: This function expects the lock to be held by the caller
+func (l *Logger) waitStalled() {
+	if l.stalled == nil {
+		return
+	}
+
+	<-l.stalled
+	l.stalled = nil
+}
+
+// SetWriteTimeout will set the maximum duration of each write, zero (the default) disables the timeout
+// Note: When a write exceeds the timeout, ErrWriteTimeout is returned and the logger is degraded until the stalled write
+// completes in the background. While degraded, writes wait up to the timeout for the stalled write before returning
+// ErrWriteTimeout. Entries which time out are not counted towards the line or byte limits. Flush and Close wait for
+// a stalled write to complete
+func (l *Logger) SetWriteTimeout(timeout time.Duration) (err error) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	// Ensure the logger has not been closed
+	if l.isClosed() {
+		// Instance of logger has been closed, return
+		return errors.ErrIsClosed
+	}
+
+	if timeout < 0 {
+		timeout = 0
+	}
+
+	l.writeTimeout = timeout
+	return
+}
+
+// Degraded will return whether or not a write has timed out and is still stalled
+func (l *Logger) Degraded() (degraded bool) {
+	// Acquire lock
+	l.mu.Lock()
+	// Defer the release of our lock
+	defer l.mu.Unlock()
+
+	if l.stalled == nil {
+		return false
+	}
+
+	select {
+	case <-l.stalled:
+		// Stalled write has completed
+		l.stalled = nil
+		return false
+	default:
+		return true
+	}
+}
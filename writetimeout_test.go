@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSetWriteTimeout(t *testing.T) {
+	var (
+		l *Logger
+
+		pr, pw *os.File
+		err    error
+	)
+
+	if err = os.MkdirAll(testDir, 0744); err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if l, err = NewWithOptions(testDir, testName, WithWriteTimeout(time.Millisecond*50)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace the underlying file with a pipe which is not read from, the small buffer ensures entries are written
+	// immediately
+	if pr, pw, err = os.Pipe(); err != nil {
+		t.Fatal(err)
+	}
+	defer pr.Close()
+
+	f, w := l.f, l.w
+	l.f = pw
+	l.w = bufio.NewWriterSize(pw, 16)
+
+	// Message exceeds the capacity of the pipe, so the write blocks
+	start := time.Now()
+	if err = l.Log(bytes.Repeat([]byte("a"), 1024*1024)); err != ErrWriteTimeout {
+		t.Fatalf("invalid error, expected %v and received %v", ErrWriteTimeout, err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("invalid write duration, expected less than %v and received %v", time.Second, elapsed)
+	}
+
+	if !l.Degraded() {
+		t.Fatal("invalid degraded state, expected true and received false")
+	}
+
+	// Stalled write has not completed, subsequent writes also time out
+	if err = l.LogString("#2"); err != ErrWriteTimeout {
+		t.Fatalf("invalid error, expected %v and received %v", ErrWriteTimeout, err)
+	}
+
+	// Unblock the pipe, allowing the stalled write to complete
+	read := make(chan []byte)
+	go func() {
+		bs, _ := ioutil.ReadAll(pr)
+		read <- bs
+	}()
+
+	for l.Degraded() {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	if err = l.LogString("#3"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restore the underlying file
+	l.mu.Lock()
+	l.f, l.w = f, w
+	l.mu.Unlock()
+
+	pw.Close()
+	bs := <-read
+
+	if !bytes.HasSuffix(bs, []byte("#3\n")) {
+		t.Fatalf("invalid contents, expected suffix %q and received %q", "#3\n", bs[len(bs)-16:])
+	}
+
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+}